@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"monoex_backend/database"
+	"monoex_backend/internal/app"
+	"monoex_backend/internal/config"
+	"monoex_backend/internal/mailer"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/services"
+
+	"golang.org/x/term"
+
+	_ "github.com/lib/pq"
+)
+
+const usage = `Usage:
+  monoex serve                                                 запустить HTTP-сервер
+  monoex admin create --username NAME [--email E] [--role R]   создать админа (пароль читается из stdin)
+
+Роли: superadmin (по умолчанию), publisher, editor
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "serve":
+		err = serve()
+	case "admin":
+		err = adminCommand(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+}
+
+func serve() error {
+	myApp := app.New()
+	if err := myApp.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize app: %w", err)
+	}
+	return myApp.Run()
+}
+
+func adminCommand(args []string) error {
+	if len(args) < 1 || args[0] != "create" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("admin create", flag.ExitOnError)
+	username := fs.String("username", "", "логин нового админа")
+	email := fs.String("email", "", "email для сброса пароля")
+	role := fs.String("role", models.RoleSuperadmin, "роль: superadmin, publisher или editor")
+	fs.Parse(args[1:])
+
+	if *username == "" {
+		return errors.New("--username is required")
+	}
+
+	password, err := readPassword(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := sql.Open(cfg.DB.Driver, cfg.DB.DSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := database.RunMigrations(); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	adminService := services.NewAdminService(db, cfg.Auth, mailer.New(cfg.SMTP))
+	admin, err := adminService.CreateAdmin(context.Background(), *username, *email, password, *role)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ Admin %q created (id=%d, role=%s)", admin.Username, admin.ID, admin.Role)
+	return nil
+}
+
+// readPassword читает пароль первой строкой из r, так он не попадает в историю shell
+// и список процессов. С терминала пароль вводится без эха.
+func readPassword(r io.Reader) (string, error) {
+	var line string
+	if f, ok := r.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fmt.Fprint(os.Stderr, "Password: ")
+		b, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		line = string(b)
+	} else {
+		s, err := bufio.NewReader(r).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		line = s
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("password is required")
+	}
+	return password, nil
+}
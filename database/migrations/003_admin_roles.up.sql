@@ -0,0 +1,5 @@
+-- Существующий админ становится суперадмином, новые аккаунты по умолчанию — редакторы
+ALTER TABLE admins ADD COLUMN role TEXT NOT NULL DEFAULT 'superadmin'
+    CHECK (role IN ('superadmin', 'editor', 'publisher'));
+
+ALTER TABLE admins ALTER COLUMN role SET DEFAULT 'editor';
@@ -0,0 +1,3 @@
+ALTER TABLE admins DROP COLUMN IF EXISTS disabled_at;
+ALTER TABLE admins DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE admins DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,3 @@
+ALTER TABLE admins ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT now();
+ALTER TABLE admins ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT now();
+ALTER TABLE admins ADD COLUMN disabled_at TIMESTAMP;
@@ -0,0 +1,9 @@
+-- Счётчики неудачных входов: scope = 'username' или 'ip'
+CREATE TABLE IF NOT EXISTS login_attempts (
+    scope TEXT NOT NULL CHECK (scope IN ('username', 'ip')),
+    key TEXT NOT NULL,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP NOT NULL DEFAULT now(),
+    blocked_until TIMESTAMP,
+    PRIMARY KEY (scope, key)
+);
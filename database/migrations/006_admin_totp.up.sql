@@ -0,0 +1,14 @@
+ALTER TABLE admins ADD COLUMN totp_secret TEXT;
+ALTER TABLE admins ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT false;
+-- Последний принятый 30-секундный шаг, чтобы один код нельзя было использовать дважды
+ALTER TABLE admins ADD COLUMN totp_last_step BIGINT;
+
+CREATE TABLE IF NOT EXISTS admin_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    admin_id INTEGER NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_recovery_codes_admin_id ON admin_recovery_codes (admin_id);
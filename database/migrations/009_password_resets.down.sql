@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS admin_password_resets;
+DROP INDEX IF EXISTS idx_admins_email;
+ALTER TABLE admins DROP COLUMN IF EXISTS email;
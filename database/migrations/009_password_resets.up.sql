@@ -0,0 +1,13 @@
+ALTER TABLE admins ADD COLUMN email TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admins_email ON admins (lower(email));
+
+-- Одноразовые токены сброса пароля; храним только SHA-256 токена
+CREATE TABLE IF NOT EXISTS admin_password_resets (
+    token_hash TEXT PRIMARY KEY,
+    admin_id INTEGER NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_password_resets_admin_id ON admin_password_resets (admin_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_news_unpublish_at;
+DROP INDEX IF EXISTS idx_news_publish_at;
+ALTER TABLE news DROP COLUMN IF EXISTS unpublish_at;
+ALTER TABLE news DROP COLUMN IF EXISTS publish_at;
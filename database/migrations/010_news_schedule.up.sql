@@ -0,0 +1,6 @@
+-- Время автоматической публикации и снятия с публикации (UTC)
+ALTER TABLE news ADD COLUMN publish_at TIMESTAMP;
+ALTER TABLE news ADD COLUMN unpublish_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_news_publish_at ON news (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_news_unpublish_at ON news (unpublish_at) WHERE unpublish_at IS NOT NULL;
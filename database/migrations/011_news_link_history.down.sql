@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS news_link_reserved ON news;
+DROP TABLE IF EXISTS news_link_history;
+DROP FUNCTION IF EXISTS news_link_reserved();
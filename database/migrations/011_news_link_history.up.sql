@@ -0,0 +1,36 @@
+-- Прежние ссылки новостей: /news/by-link/{старая} отвечает 301 на текущую
+CREATE TABLE IF NOT EXISTS news_link_history (
+    link TEXT PRIMARY KEY CHECK (link !~ '["/]'),
+    news_id INTEGER NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+    retired_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_link_history_news_id ON news_link_history (news_id);
+
+-- Старая ссылка остаётся за своей новостью: другая новость не может её занять,
+-- и наоборот — нельзя увести в историю ссылку, которую носит другая новость.
+-- Ошибка с кодом 23505, как у обычного UNIQUE.
+CREATE OR REPLACE FUNCTION news_link_reserved() RETURNS trigger AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'news' THEN
+        IF EXISTS (SELECT 1 FROM news_link_history WHERE link = NEW.link AND news_id <> NEW.id) THEN
+            RAISE EXCEPTION 'link "%" is reserved by another news', NEW.link
+                USING ERRCODE = 'unique_violation', CONSTRAINT = 'news_link_reserved';
+        END IF;
+    ELSE
+        IF EXISTS (SELECT 1 FROM news WHERE link = NEW.link AND id <> NEW.news_id) THEN
+            RAISE EXCEPTION 'link "%" is used by another news', NEW.link
+                USING ERRCODE = 'unique_violation', CONSTRAINT = 'news_link_reserved';
+        END IF;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER news_link_reserved
+    BEFORE INSERT OR UPDATE OF link ON news
+    FOR EACH ROW EXECUTE PROCEDURE news_link_reserved();
+
+CREATE TRIGGER news_link_history_reserved
+    BEFORE INSERT OR UPDATE ON news_link_history
+    FOR EACH ROW EXECUTE PROCEDURE news_link_reserved();
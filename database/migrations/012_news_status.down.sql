@@ -0,0 +1,4 @@
+ALTER TABLE news DROP CONSTRAINT IF EXISTS news_status_check;
+ALTER TABLE news ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE news ALTER COLUMN status SET DEFAULT 'not_published';
+UPDATE news SET status = 'not_published' WHERE status <> 'published';
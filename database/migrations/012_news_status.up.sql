@@ -0,0 +1,8 @@
+-- Единый набор статусов: draft → in_review → published → archived
+UPDATE news SET status = 'draft'
+WHERE status IS NULL OR status NOT IN ('draft', 'in_review', 'published', 'archived');
+
+ALTER TABLE news ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE news ALTER COLUMN status SET NOT NULL;
+ALTER TABLE news ADD CONSTRAINT news_status_check
+    CHECK (status IN ('draft', 'in_review', 'published', 'archived'));
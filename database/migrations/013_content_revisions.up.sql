@@ -0,0 +1,19 @@
+-- Неизменяемые ревизии новостей и законов: полный снимок при каждом сохранении
+CREATE TABLE IF NOT EXISTS content_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type TEXT NOT NULL CHECK (entity_type IN ('news', 'legislation')),
+    entity_id INTEGER NOT NULL,
+    revision INTEGER NOT NULL,
+    author_id INTEGER REFERENCES admins(id) ON DELETE SET NULL,
+    author_name TEXT NOT NULL DEFAULT '',
+    content JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (entity_type, entity_id, revision)
+);
+
+-- Текущее состояние существующих записей становится первой ревизией
+INSERT INTO content_revisions (entity_type, entity_id, revision, content, created_at)
+SELECT 'news', n.id, 1, to_jsonb(n), n.updated_at FROM news n;
+
+INSERT INTO content_revisions (entity_type, entity_id, revision, content, created_at)
+SELECT 'legislation', l.id, 1, to_jsonb(l), l.updated_at FROM legislations l;
@@ -0,0 +1,31 @@
+-- Рубрики (ведёт редакция) и теги (создаются при назначении новости)
+CREATE TABLE IF NOT EXISTS categories (
+    id SERIAL PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS news_categories (
+    news_id INTEGER NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    PRIMARY KEY (news_id, category_id)
+);
+
+CREATE TABLE IF NOT EXISTS news_tags (
+    news_id INTEGER NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (news_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_categories_category_id ON news_categories (category_id);
+CREATE INDEX IF NOT EXISTS idx_news_tags_tag_id ON news_tags (tag_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_news_search_vector;
+ALTER TABLE news DROP COLUMN IF EXISTS search_vector;
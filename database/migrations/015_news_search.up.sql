@@ -0,0 +1,10 @@
+-- Полнотекстовый поиск по новостям (русская морфология).
+-- Заголовок весит больше описания, описание — больше текста.
+ALTER TABLE news ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+        setweight(to_tsvector('russian', coalesce(full_text, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_news_search_vector ON news USING GIN (search_vector);
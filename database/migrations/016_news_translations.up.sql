@@ -0,0 +1,17 @@
+-- Переводы новостей. Основные поля news — язык по умолчанию (i18n.default_language),
+-- здесь — остальные языки, у каждого своя ссылка и свой флаг публикации.
+CREATE TABLE IF NOT EXISTS news_translations (
+    news_id INTEGER NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+    language TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    full_text TEXT NOT NULL DEFAULT '',
+    link TEXT NOT NULL CHECK (link !~ '["/]'),
+    published BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (news_id, language),
+    UNIQUE (language, link)
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_translations_link ON news_translations (link);
@@ -0,0 +1,12 @@
+-- Ссылки предпросмотра неопубликованных новостей. Сам токен подписан и не хранится,
+-- здесь — его jti для отзыва и списка выданных ссылок.
+CREATE TABLE IF NOT EXISTS news_preview_tokens (
+    id TEXT PRIMARY KEY,
+    news_id INTEGER NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES admins(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_preview_tokens_news_id ON news_preview_tokens (news_id);
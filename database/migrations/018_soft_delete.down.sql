@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_reviews_deleted_at;
+DROP INDEX IF EXISTS idx_legislations_deleted_at;
+DROP INDEX IF EXISTS idx_news_deleted_at;
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE legislations DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE news DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,9 @@
+-- Мягкое удаление: запись с deleted_at лежит в корзине и не видна ни в списках, ни на сайте.
+-- Через trash.retention_days дней её окончательно удаляет планировщик.
+ALTER TABLE news ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE legislations ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_news_deleted_at ON news (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_legislations_deleted_at ON legislations (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_reviews_deleted_at ON reviews (deleted_at) WHERE deleted_at IS NOT NULL;
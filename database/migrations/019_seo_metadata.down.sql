@@ -0,0 +1,11 @@
+ALTER TABLE legislations
+    DROP COLUMN IF EXISTS og_image,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title;
+
+ALTER TABLE news
+    DROP COLUMN IF EXISTS og_image,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title;
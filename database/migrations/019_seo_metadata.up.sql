@@ -0,0 +1,13 @@
+-- SEO-поля новостей и законов. Пустая строка — значение по умолчанию:
+-- заголовок, описание, страница на сайте и изображение самой записи.
+ALTER TABLE news
+    ADD COLUMN IF NOT EXISTS meta_title TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS meta_description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS canonical_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS og_image TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE legislations
+    ADD COLUMN IF NOT EXISTS meta_title TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS meta_description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS canonical_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS og_image TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_news_featured;
+DROP INDEX IF EXISTS idx_news_pinned;
+
+ALTER TABLE news
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS featured_until,
+    DROP COLUMN IF EXISTS featured,
+    DROP COLUMN IF EXISTS pinned;
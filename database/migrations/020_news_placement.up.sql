@@ -0,0 +1,10 @@
+-- Размещение новостей на главной: закреплённые идут первыми, избранные — в блоке «главное».
+-- priority задаёт ручной порядок (меньше — выше), featured_until — конец показа в избранном.
+ALTER TABLE news
+    ADD COLUMN IF NOT EXISTS pinned BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS featured BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS featured_until TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS priority INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_news_pinned ON news (priority, created_at DESC) WHERE pinned;
+CREATE INDEX IF NOT EXISTS idx_news_featured ON news (priority, created_at DESC) WHERE featured;
@@ -0,0 +1,34 @@
+-- Авторы публикаций. Основные поля — язык по умолчанию, переводы — в author_translations.
+-- Автор не аккаунт: админа можно (необязательно) связать с одним автором.
+CREATE TABLE IF NOT EXISTS authors (
+    id SERIAL PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    position TEXT NOT NULL DEFAULT '',
+    photo_path TEXT NOT NULL DEFAULT '',
+    bio TEXT NOT NULL DEFAULT '',
+    admin_id INTEGER UNIQUE REFERENCES admins(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS author_translations (
+    author_id INTEGER NOT NULL REFERENCES authors(id) ON DELETE CASCADE,
+    language TEXT NOT NULL,
+    name TEXT NOT NULL,
+    position TEXT NOT NULL DEFAULT '',
+    bio TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (author_id, language)
+);
+
+-- position — порядок авторов в подписи новости
+CREATE TABLE IF NOT EXISTS news_authors (
+    news_id INTEGER NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+    author_id INTEGER NOT NULL REFERENCES authors(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (news_id, author_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_news_authors_author_id ON news_authors (author_id);
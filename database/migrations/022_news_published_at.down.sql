@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_news_published_at;
+ALTER TABLE news DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1,10 @@
+-- Фактическое время публикации: ставится при переходе в published (вручную или по расписанию).
+-- Ленты и метаданные берут дату отсюда, а не из created_at.
+ALTER TABLE news ADD COLUMN IF NOT EXISTS published_at TIMESTAMP;
+
+-- Для уже опубликованных — как раньше: плановое время публикации, иначе время создания
+UPDATE news SET published_at = COALESCE(publish_at, created_at)
+WHERE status IN ('published', 'archived') AND published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_news_published_at
+    ON news ((COALESCE(published_at, publish_at, created_at)) DESC) WHERE deleted_at IS NULL;
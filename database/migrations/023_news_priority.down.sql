@@ -0,0 +1,2 @@
+-- Перенумерация priority не откатывается: прежний порядок (все 0) не несёт информации
+SELECT 1;
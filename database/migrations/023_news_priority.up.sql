@@ -0,0 +1,12 @@
+-- Закреплённые и избранные без ручного места (priority = 0) ставим после расставленных,
+-- новые сверху; у остальных новостей priority не используется — сбрасываем в 0
+UPDATE news SET priority = 0 WHERE NOT (pinned OR featured) AND priority <> 0;
+
+WITH unplaced AS (
+    SELECT id,
+        (SELECT COALESCE(MAX(priority), 0) FROM news WHERE pinned OR featured)
+            + ROW_NUMBER() OVER (ORDER BY COALESCE(published_at, publish_at, created_at) DESC, id) AS position
+    FROM news
+    WHERE (pinned OR featured) AND priority = 0
+)
+UPDATE news SET priority = u.position FROM unplaced u WHERE news.id = u.id;
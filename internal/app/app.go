@@ -2,12 +2,15 @@ package app
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"monoex_backend/database"
 	"monoex_backend/internal/config"
 	"monoex_backend/internal/handlers"
+	"monoex_backend/internal/mailer"
 	"monoex_backend/internal/middleware"
 	"monoex_backend/internal/repositories"
 	"monoex_backend/internal/routes"
@@ -38,6 +41,8 @@ type App struct {
 	newsService        *services.NewsService
 	reviewService      *services.ReviewService
 	adminService       *services.AdminService
+	auditService       *services.AuditService
+	trashService       *services.TrashService
 
 	// Handlers
 	legislationHandler *handlers.LegislationHandler
@@ -58,6 +63,16 @@ func (a *App) Initialize() error {
 	}
 	a.config = cfg
 
+	// Без секрета токены подписываем случайным ключом: сессии не переживут рестарт
+	if a.config.Auth.TokenSecret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return fmt.Errorf("failed to generate token secret: %w", err)
+		}
+		a.config.Auth.TokenSecret = hex.EncodeToString(secret)
+		log.Println("⚠️ AUTH_TOKEN_SECRET is not set, using a random secret for this run")
+	}
+
 	// Initialize database (sql.DB) using DSN from config
 	if err := a.initDB(); err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
@@ -114,10 +129,13 @@ func (a *App) initRepositories() {
 }
 
 func (a *App) initServices() {
-	a.legislationService = services.NewLegislationService(a.legislationRepo)
-	a.newsService = services.NewNewsService(a.newsRepo)
-	a.reviewService = services.NewReviewService(a.reviewRepo)
-	a.adminService = services.NewAdminService(a.db)
+	a.auditService = services.NewAuditService(a.db)
+	revisionService := services.NewRevisionService(a.db)
+	a.legislationService = services.NewLegislationService(a.db, a.legislationRepo, a.auditService, revisionService)
+	a.newsService = services.NewNewsService(a.db, a.newsRepo, a.auditService, revisionService, a.config.I18n)
+	a.reviewService = services.NewReviewService(a.db, a.reviewRepo, a.auditService)
+	a.trashService = services.NewTrashService(a.db, a.config.Trash, a.auditService)
+	a.adminService = services.NewAdminService(a.db, a.config.Auth, mailer.New(a.config.SMTP))
 }
 
 func (a *App) initHandlers() {
@@ -132,17 +150,18 @@ func (a *App) setupRoutes() {
 
 	// Apply global middleware
 	a.router.Use(middleware.CORS())
+	a.router.Use(middleware.RealIP(a.config.Server.TrustProxy))
 	a.router.Use(middleware.Logging())
 	a.router.Use(middleware.Recovery())
 
 	// ✅ Health-check (можно открыть в браузере /health)
 	a.router.HandleFunc("/health", a.healthCheck).Methods("GET")
 
-	// ✅ Регистрация единственного админа (без middleware)
+	// ✅ Регистрация первого админа (только с setup-токеном из конфига)
 	a.router.HandleFunc("/register-admin", a.adminHandler.Register).Methods("POST")
 
 	// ✅ Подключаем API роуты с админским middleware
-	routes.RegisterAllRoutes(a.router, a.db, a.adminService)
+	routes.RegisterAllRoutes(a.router, a.db, a.config, a.adminService)
 
 	// ✅ Раздача загруженных файлов (статические файлы)
 	uploadDir := "./uploads"
@@ -160,6 +179,11 @@ func (a *App) setupServer() {
 }
 
 func (a *App) Run() error {
+	// Фоновый планировщик публикаций живёт до остановки сервера
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go a.runScheduler(schedulerCtx)
+
 	// Start server in goroutine
 	go func() {
 		log.Printf("Server starting on port %s", a.config.Server.Port)
@@ -174,6 +198,7 @@ func (a *App) Run() error {
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopScheduler()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -192,6 +217,38 @@ func (a *App) Run() error {
 	return nil
 }
 
+// runScheduler раз в scheduler.interval публикует и снимает новости по расписанию
+// и очищает корзину от записей старше trash.retention_days.
+// Несколько экземпляров приложения не мешают друг другу (FOR UPDATE SKIP LOCKED).
+func (a *App) runScheduler(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(a.config.Scheduler.Interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		n, err := a.newsService.ApplySchedule(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("⚠️ News scheduler failed: %v", err)
+		}
+		if n > 0 {
+			log.Printf("🕒 News scheduler changed status of %d news", n)
+		}
+
+		purged, err := a.trashService.PurgeExpired(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("⚠️ Trash cleanup failed: %v", err)
+		}
+		if purged > 0 {
+			log.Printf("🗑️ Trash cleanup permanently deleted %d items", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (a *App) healthCheck(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -1,17 +1,27 @@
 package config
 
 import (
+	"fmt"
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
 	"log"
+	"math"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Основная структура конфига
 type Config struct {
-	Server ServerConfig   `mapstructure:"server" yaml:"server"`
-	DB     DatabaseConfig `mapstructure:"database" yaml:"database"`
+	Server    ServerConfig    `mapstructure:"server" yaml:"server"`
+	DB        DatabaseConfig  `mapstructure:"database" yaml:"database"`
+	Auth      AuthConfig      `mapstructure:"auth" yaml:"auth"`
+	SMTP      SMTPConfig      `mapstructure:"smtp" yaml:"smtp"`
+	Scheduler SchedulerConfig `mapstructure:"scheduler" yaml:"scheduler"`
+	I18n      I18nConfig      `mapstructure:"i18n" yaml:"i18n"`
+	Site      SiteConfig      `mapstructure:"site" yaml:"site"`
+	Trash     TrashConfig     `mapstructure:"trash" yaml:"trash"`
 }
 
 type ServerConfig struct {
@@ -20,6 +30,7 @@ type ServerConfig struct {
 	ReadTimeout  int    `mapstructure:"read_timeout" yaml:"read_timeout"`
 	WriteTimeout int    `mapstructure:"write_timeout" yaml:"write_timeout"`
 	IdleTimeout  int    `mapstructure:"idle_timeout" yaml:"idle_timeout"`
+	TrustProxy   bool   `mapstructure:"trust_proxy" yaml:"trust_proxy"` // брать IP клиента из X-Forwarded-For/X-Real-IP
 }
 
 type DatabaseConfig struct {
@@ -30,6 +41,77 @@ type DatabaseConfig struct {
 	ConnMaxLifetime int    `mapstructure:"conn_max_lifetime" yaml:"conn_max_lifetime"` // в минутах
 }
 
+type AuthConfig struct {
+	TokenSecret     string         `mapstructure:"token_secret" yaml:"token_secret"`
+	AccessTokenTTL  int            `mapstructure:"access_token_ttl" yaml:"access_token_ttl"`   // в минутах
+	RefreshTokenTTL int            `mapstructure:"refresh_token_ttl" yaml:"refresh_token_ttl"` // в часах
+	SetupToken      string         `mapstructure:"setup_token" yaml:"setup_token"`             // пусто — HTTP-регистрация выключена
+	Lockout         LockoutConfig  `mapstructure:"lockout" yaml:"lockout"`
+	TOTPIssuer      string         `mapstructure:"totp_issuer" yaml:"totp_issuer"` // имя в приложении-аутентификаторе
+	Password        PasswordConfig `mapstructure:"password" yaml:"password"`
+	ResetTokenTTL   int            `mapstructure:"reset_token_ttl" yaml:"reset_token_ttl"`     // в минутах
+	ResetURL        string         `mapstructure:"reset_url" yaml:"reset_url"`                 // страница фронта, токен добавляется как ?token=
+	PreviewTokenTTL int            `mapstructure:"preview_token_ttl" yaml:"preview_token_ttl"` // в часах, срок ссылки предпросмотра по умолчанию
+}
+
+// Хэширование паролей и парольная политика.
+// Хэши со старыми параметрами пересчитываются при следующем успешном входе.
+type PasswordConfig struct {
+	Algorithm         string `mapstructure:"algorithm" yaml:"algorithm"`                   // argon2id или bcrypt
+	Argon2Memory      int    `mapstructure:"argon2_memory" yaml:"argon2_memory"`           // в КиБ
+	Argon2Iterations  int    `mapstructure:"argon2_iterations" yaml:"argon2_iterations"`   // проходов по памяти
+	Argon2Parallelism int    `mapstructure:"argon2_parallelism" yaml:"argon2_parallelism"` // потоков
+	BcryptCost        int    `mapstructure:"bcrypt_cost" yaml:"bcrypt_cost"`
+	MinLength         int    `mapstructure:"min_length" yaml:"min_length"`
+	WeakListPath      string `mapstructure:"weak_list_path" yaml:"weak_list_path"` // дополнительный список слабых паролей, по одному в строке
+}
+
+// Фоновые задачи (публикация новостей по расписанию)
+type SchedulerConfig struct {
+	Interval int `mapstructure:"interval" yaml:"interval"` // в секундах, больше нуля
+}
+
+// Языки сайта. Основные поля новости — на DefaultLanguage, остальные языки — переводы.
+// Если перевода на запрошенный язык нет, отдаём FallbackLanguage (а без него — основной).
+type I18nConfig struct {
+	DefaultLanguage  string   `mapstructure:"default_language" yaml:"default_language"`
+	Languages        []string `mapstructure:"languages" yaml:"languages"`
+	FallbackLanguage string   `mapstructure:"fallback_language" yaml:"fallback_language"`
+}
+
+// Публичный сайт: абсолютные ссылки в лентах, sitemap и SEO-метаданных.
+// Страница новости — BaseURL + NewsPath + link, закона — BaseURL + LegislationPath + id.
+type SiteConfig struct {
+	BaseURL         string `mapstructure:"base_url" yaml:"base_url"`                 // без завершающего /
+	Name            string `mapstructure:"name" yaml:"name"`                         // заголовок лент, og:site_name
+	NewsPath        string `mapstructure:"news_path" yaml:"news_path"`               // путь страниц новостей на фронте
+	LegislationPath string `mapstructure:"legislation_path" yaml:"legislation_path"` // путь страниц законов на фронте
+}
+
+// Корзина: удалённый контент хранится RetentionDays дней, потом удаляется вместе с файлами.
+// Отрицательное значение отключает автоматическое удаление.
+type TrashConfig struct {
+	RetentionDays int `mapstructure:"retention_days" yaml:"retention_days"`
+}
+
+// Почта для писем сброса пароля. Локально — MailHog на localhost:1025 без авторизации.
+type SMTPConfig struct {
+	Host     string `mapstructure:"host" yaml:"host"`
+	Port     int    `mapstructure:"port" yaml:"port"`
+	Username string `mapstructure:"username" yaml:"username"` // пусто — без авторизации
+	Password string `mapstructure:"password" yaml:"password"`
+	From     string `mapstructure:"from" yaml:"from"`
+}
+
+// Защита от подбора пароля: счётчики по логину и по IP
+type LockoutConfig struct {
+	FreeAttempts       int `mapstructure:"free_attempts" yaml:"free_attempts"`               // ошибок без задержки
+	BackoffBaseSeconds int `mapstructure:"backoff_base_seconds" yaml:"backoff_base_seconds"` // первая задержка, дальше удваивается
+	BackoffMaxSeconds  int `mapstructure:"backoff_max_seconds" yaml:"backoff_max_seconds"`
+	MaxAttempts        int `mapstructure:"max_attempts" yaml:"max_attempts"`       // после стольких ошибок — блокировка
+	LockoutMinutes     int `mapstructure:"lockout_minutes" yaml:"lockout_minutes"` // длительность блокировки и окно сброса счётчика
+}
+
 var AppConfig *Config
 
 func Load() (*Config, error) {
@@ -52,9 +134,24 @@ func Load() (*Config, error) {
 
 	applyEnvOverrides(&cfg)
 
+	// time.NewTicker паникует на интервале <= 0
+	if cfg.Scheduler.Interval <= 0 {
+		return nil, fmt.Errorf("scheduler.interval must be a positive number of seconds, got %d", cfg.Scheduler.Interval)
+	}
+	// argon2.IDKey паникует на нулевых параметрах, а приведение к uint8/uint32 молча обрезает значения
+	if err := validatePassword(cfg.Auth.Password); err != nil {
+		return nil, err
+	}
+
 	// 🔹 Разворачиваем переменные в DSN и Port
 	cfg.DB.DSN = os.ExpandEnv(cfg.DB.DSN)
 	cfg.Server.Port = os.ExpandEnv(cfg.Server.Port)
+	cfg.Auth.TokenSecret = os.ExpandEnv(cfg.Auth.TokenSecret)
+	cfg.Auth.SetupToken = os.ExpandEnv(cfg.Auth.SetupToken)
+	cfg.Auth.ResetURL = os.ExpandEnv(cfg.Auth.ResetURL)
+	cfg.SMTP.Username = os.ExpandEnv(cfg.SMTP.Username)
+	cfg.SMTP.Password = os.ExpandEnv(cfg.SMTP.Password)
+	cfg.Site.BaseURL = os.ExpandEnv(cfg.Site.BaseURL)
 
 	AppConfig = &cfg
 	log.Println("✅ Config loaded successfully")
@@ -79,6 +176,23 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// validatePassword проверяет параметры хэширования паролей при запуске, а не при первом входе
+func validatePassword(p PasswordConfig) error {
+	if p.Argon2Memory <= 0 || int64(p.Argon2Memory) > math.MaxUint32 {
+		return fmt.Errorf("auth.password.argon2_memory must be between 1 and %d KiB, got %d", uint32(math.MaxUint32), p.Argon2Memory)
+	}
+	if p.Argon2Iterations <= 0 || int64(p.Argon2Iterations) > math.MaxUint32 {
+		return fmt.Errorf("auth.password.argon2_iterations must be between 1 and %d, got %d", uint32(math.MaxUint32), p.Argon2Iterations)
+	}
+	if p.Argon2Parallelism < 1 || p.Argon2Parallelism > 255 {
+		return fmt.Errorf("auth.password.argon2_parallelism must be between 1 and 255, got %d", p.Argon2Parallelism)
+	}
+	if p.BcryptCost < bcrypt.MinCost || p.BcryptCost > bcrypt.MaxCost {
+		return fmt.Errorf("auth.password.bcrypt_cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, p.BcryptCost)
+	}
+	return nil
+}
+
 func getEnvAsInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if v, err := strconv.Atoi(value); err == nil {
@@ -88,6 +202,28 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsList(key string, defaultValue []string) []string {
+	if value := os.Getenv(key); value != "" {
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return defaultValue
+}
+
 func applyEnvOverrides(cfg *Config) {
 	// Server
 	if cfg.Server.Host == "" {
@@ -105,6 +241,9 @@ func applyEnvOverrides(cfg *Config) {
 	if cfg.Server.IdleTimeout == 0 {
 		cfg.Server.IdleTimeout = getEnvAsInt("SERVER_IDLE_TIMEOUT", 60)
 	}
+	if !cfg.Server.TrustProxy {
+		cfg.Server.TrustProxy = getEnvAsBool("SERVER_TRUST_PROXY", false)
+	}
 
 	// Database
 	if cfg.DB.Driver == "" {
@@ -122,4 +261,119 @@ func applyEnvOverrides(cfg *Config) {
 	if cfg.DB.ConnMaxLifetime == 0 {
 		cfg.DB.ConnMaxLifetime = getEnvAsInt("DB_CONN_MAX_LIFETIME", 5)
 	}
+
+	// Auth
+	if cfg.Auth.TokenSecret == "" {
+		cfg.Auth.TokenSecret = getEnv("AUTH_TOKEN_SECRET", "")
+	}
+	if cfg.Auth.SetupToken == "" {
+		cfg.Auth.SetupToken = getEnv("AUTH_SETUP_TOKEN", "")
+	}
+	if cfg.Auth.AccessTokenTTL == 0 {
+		cfg.Auth.AccessTokenTTL = getEnvAsInt("AUTH_ACCESS_TOKEN_TTL", 15)
+	}
+	if cfg.Auth.RefreshTokenTTL == 0 {
+		cfg.Auth.RefreshTokenTTL = getEnvAsInt("AUTH_REFRESH_TOKEN_TTL", 720)
+	}
+	if cfg.Auth.TOTPIssuer == "" {
+		cfg.Auth.TOTPIssuer = getEnv("AUTH_TOTP_ISSUER", "Monoex")
+	}
+	if cfg.Auth.ResetTokenTTL == 0 {
+		cfg.Auth.ResetTokenTTL = getEnvAsInt("AUTH_RESET_TOKEN_TTL", 30)
+	}
+	if cfg.Auth.ResetURL == "" {
+		cfg.Auth.ResetURL = getEnv("AUTH_RESET_URL", "")
+	}
+	if cfg.Auth.PreviewTokenTTL == 0 {
+		cfg.Auth.PreviewTokenTTL = getEnvAsInt("AUTH_PREVIEW_TOKEN_TTL", 72)
+	}
+	if cfg.Auth.Password.Algorithm == "" {
+		cfg.Auth.Password.Algorithm = getEnv("AUTH_PASSWORD_ALGORITHM", "argon2id")
+	}
+	if cfg.Auth.Password.Argon2Memory == 0 {
+		cfg.Auth.Password.Argon2Memory = getEnvAsInt("AUTH_PASSWORD_ARGON2_MEMORY", 64*1024)
+	}
+	if cfg.Auth.Password.Argon2Iterations == 0 {
+		cfg.Auth.Password.Argon2Iterations = getEnvAsInt("AUTH_PASSWORD_ARGON2_ITERATIONS", 3)
+	}
+	if cfg.Auth.Password.Argon2Parallelism == 0 {
+		cfg.Auth.Password.Argon2Parallelism = getEnvAsInt("AUTH_PASSWORD_ARGON2_PARALLELISM", 2)
+	}
+	if cfg.Auth.Password.BcryptCost == 0 {
+		cfg.Auth.Password.BcryptCost = getEnvAsInt("AUTH_PASSWORD_BCRYPT_COST", 12)
+	}
+	if cfg.Auth.Password.MinLength == 0 {
+		cfg.Auth.Password.MinLength = getEnvAsInt("AUTH_PASSWORD_MIN_LENGTH", 10)
+	}
+	if cfg.Auth.Password.WeakListPath == "" {
+		cfg.Auth.Password.WeakListPath = getEnv("AUTH_PASSWORD_WEAK_LIST_PATH", "")
+	}
+	if cfg.Auth.Lockout.FreeAttempts == 0 {
+		cfg.Auth.Lockout.FreeAttempts = getEnvAsInt("AUTH_LOCKOUT_FREE_ATTEMPTS", 3)
+	}
+	if cfg.Auth.Lockout.BackoffBaseSeconds == 0 {
+		cfg.Auth.Lockout.BackoffBaseSeconds = getEnvAsInt("AUTH_LOCKOUT_BACKOFF_BASE_SECONDS", 1)
+	}
+	if cfg.Auth.Lockout.BackoffMaxSeconds == 0 {
+		cfg.Auth.Lockout.BackoffMaxSeconds = getEnvAsInt("AUTH_LOCKOUT_BACKOFF_MAX_SECONDS", 60)
+	}
+	if cfg.Auth.Lockout.MaxAttempts == 0 {
+		cfg.Auth.Lockout.MaxAttempts = getEnvAsInt("AUTH_LOCKOUT_MAX_ATTEMPTS", 10)
+	}
+	if cfg.Auth.Lockout.LockoutMinutes == 0 {
+		cfg.Auth.Lockout.LockoutMinutes = getEnvAsInt("AUTH_LOCKOUT_MINUTES", 15)
+	}
+
+	// SMTP
+	if cfg.SMTP.Host == "" {
+		cfg.SMTP.Host = getEnv("SMTP_HOST", "localhost")
+	}
+	if cfg.SMTP.Port == 0 {
+		cfg.SMTP.Port = getEnvAsInt("SMTP_PORT", 1025)
+	}
+	if cfg.SMTP.Username == "" {
+		cfg.SMTP.Username = getEnv("SMTP_USERNAME", "")
+	}
+	if cfg.SMTP.Password == "" {
+		cfg.SMTP.Password = getEnv("SMTP_PASSWORD", "")
+	}
+	if cfg.SMTP.From == "" {
+		cfg.SMTP.From = getEnv("SMTP_FROM", "no-reply@monoex.local")
+	}
+
+	// Scheduler
+	if cfg.Scheduler.Interval == 0 {
+		cfg.Scheduler.Interval = getEnvAsInt("SCHEDULER_INTERVAL", 30)
+	}
+
+	// I18n
+	if cfg.I18n.DefaultLanguage == "" {
+		cfg.I18n.DefaultLanguage = getEnv("I18N_DEFAULT_LANGUAGE", "ru")
+	}
+	if len(cfg.I18n.Languages) == 0 {
+		cfg.I18n.Languages = getEnvAsList("I18N_LANGUAGES", []string{"ru", "kk", "en"})
+	}
+	if cfg.I18n.FallbackLanguage == "" {
+		cfg.I18n.FallbackLanguage = getEnv("I18N_FALLBACK_LANGUAGE", cfg.I18n.DefaultLanguage)
+	}
+
+	// Site
+	if cfg.Site.BaseURL == "" {
+		cfg.Site.BaseURL = getEnv("SITE_BASE_URL", "http://localhost:"+cfg.Server.Port)
+	}
+	cfg.Site.BaseURL = strings.TrimRight(cfg.Site.BaseURL, "/")
+	if cfg.Site.Name == "" {
+		cfg.Site.Name = getEnv("SITE_NAME", "Monoex")
+	}
+	if cfg.Site.NewsPath == "" {
+		cfg.Site.NewsPath = getEnv("SITE_NEWS_PATH", "/news/")
+	}
+	if cfg.Site.LegislationPath == "" {
+		cfg.Site.LegislationPath = getEnv("SITE_LEGISLATION_PATH", "/legislation/")
+	}
+
+	// Trash
+	if cfg.Trash.RetentionDays == 0 {
+		cfg.Trash.RetentionDays = getEnvAsInt("TRASH_RETENTION_DAYS", 30)
+	}
 }
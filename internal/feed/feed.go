@@ -0,0 +1,180 @@
+// Package feed сериализует ленту новостей в RSS 2.0 и Atom 1.0.
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Feed — лента, не зависящая от формата
+type Feed struct {
+	Title       string
+	Link        string // страница сайта, которую описывает лента
+	Self        string // адрес самой ленты
+	Description string
+	Language    string
+	Updated     time.Time
+	Items       []Item
+}
+
+type Item struct {
+	Title       string
+	Link        string
+	GUID        string // постоянный идентификатор записи (абсолютный URL)
+	Description string
+	Published   time.Time
+	Updated     time.Time
+	Categories  []string
+	Enclosure   *Enclosure
+}
+
+// Enclosure — вложение (изображение новости). Length 0 — размер неизвестен.
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int64
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	Description string        `xml:"description"`
+	PubDate     string        `xml:"pubDate"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int64  `xml:"length,attr"`
+}
+
+// RSS строит документ RSS 2.0
+func RSS(f Feed) ([]byte, error) {
+	doc := rss{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			AtomLink:      atomLink{Href: f.Self, Rel: "self", Type: "application/rss+xml"},
+			Description:   f.Description,
+			Language:      f.Language,
+			LastBuildDate: f.Updated.UTC().Format(time.RFC1123Z),
+		},
+	}
+	for _, item := range f.Items {
+		ri := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{IsPermaLink: true, Value: item.GUID},
+			Description: item.Description,
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+			Categories:  item.Categories,
+		}
+		if item.Enclosure != nil {
+			ri.Enclosure = &rssEnclosure{URL: item.Enclosure.URL, Type: item.Enclosure.Type, Length: item.Enclosure.Length}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, ri)
+	}
+	return marshal(doc)
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// Atom строит документ Atom 1.0
+func Atom(f Feed) ([]byte, error) {
+	doc := atomFeed{
+		Lang:     f.Language,
+		ID:       f.Self,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  f.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+			{Href: f.Self, Rel: "self", Type: "application/atom+xml"},
+		},
+	}
+	for _, item := range f.Items {
+		entry := atomEntry{
+			ID:        item.GUID,
+			Title:     item.Title,
+			Links:     []atomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+			Summary:   item.Description,
+		}
+		for _, c := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: c})
+		}
+		if item.Enclosure != nil {
+			entry.Links = append(entry.Links, atomLink{
+				Href: item.Enclosure.URL, Rel: "enclosure", Type: item.Enclosure.Type, Length: item.Enclosure.Length,
+			})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return marshal(doc)
+}
+
+func marshal(doc any) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -2,9 +2,14 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"monoex_backend/internal/models"
 	"monoex_backend/internal/services"
+
+	"github.com/gorilla/mux"
 )
 
 type AdminHandler struct {
@@ -16,9 +21,13 @@ func NewAdminHandler(service *services.AdminService) *AdminHandler {
 }
 
 // POST /register-admin
+// Создаёт первого суперадмина. Работает только с одноразовым setup-токеном
+// из конфига (заголовок X-Setup-Token) и только пока нет ни одного админа.
+// Основной способ — CLI: monoex admin create --username ...
 func (h *AdminHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Username string `json:"username"`
+		Email    string `json:"email"`
 		Password string `json:"password"`
 	}
 
@@ -32,8 +41,18 @@ func (h *AdminHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.service.CreateAdmin(r.Context(), req.Username, req.Password)
-	if err != nil {
+	_, err := h.service.BootstrapWithSetupToken(r.Context(), r.Header.Get("X-Setup-Token"), req.Username, req.Email, req.Password)
+	switch {
+	case errors.Is(err, services.ErrSetupDisabled):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, services.ErrInvalidToken):
+		http.Error(w, "Invalid setup token", http.StatusForbidden)
+		return
+	case errors.Is(err, services.ErrWeakPassword), errors.Is(err, services.ErrInvalidEmail):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
 		http.Error(w, err.Error(), http.StatusForbidden)
 		return
 	}
@@ -41,3 +60,458 @@ func (h *AdminHandler) Register(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(map[string]string{"status": "admin created"})
 }
+
+// POST /admin/admins (только суперадмин)
+func (h *AdminHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+		Role     string `json:"role"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if req.Role == "" {
+		req.Role = models.RoleEditor
+	}
+
+	admin, err := h.service.CreateAdmin(r.Context(), req.Username, req.Email, req.Password, req.Role)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(admin)
+}
+
+// PUT /admin/admins/{id}/role (только суперадмин)
+func (h *AdminHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.UpdateRole(r.Context(), id, req.Role); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"role": req.Role})
+}
+
+// GET /admin/admins (только суперадмин)
+func (h *AdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	admins, err := h.service.ListAdmins(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": admins})
+}
+
+// GET /admin/me
+func (h *AdminHandler) Me(w http.ResponseWriter, r *http.Request) {
+	admin, err := h.service.GetAdmin(r.Context(), services.AdminFromContext(r.Context()).ID)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(admin)
+}
+
+// PUT /admin/me/password
+func (h *AdminHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		OldPassword string `json:"old_password"`
+		NewPassword string `json:"new_password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	adminID := services.AdminFromContext(r.Context()).ID
+	if err := h.service.ChangePassword(r.Context(), adminID, req.OldPassword, req.NewPassword); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PUT /admin/me/email — адрес для сброса пароля
+func (h *AdminHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	h.setEmail(w, r, services.AdminFromContext(r.Context()).ID)
+}
+
+// PUT /admin/admins/{id}/email (только суперадмин)
+func (h *AdminHandler) UpdateEmail(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	h.setEmail(w, r, id)
+}
+
+func (h *AdminHandler) setEmail(w http.ResponseWriter, r *http.Request, id int) {
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetEmail(r.Context(), id, req.Email); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"email": req.Email})
+}
+
+// POST /admin/password/forgot — письмо со ссылкой сброса.
+// Ответ одинаковый, есть такой адрес или нет.
+func (h *AdminHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		if errors.Is(err, services.ErrInvalidEmail) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{
+		"status": "if the address is registered, a reset link has been sent",
+	})
+}
+
+// POST /admin/password/reset — новый пароль по токену из письма
+func (h *AdminHandler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ConfirmPasswordReset(r.Context(), req.Token, req.Password); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /admin/me/totp/enroll — секрет, otpauth-ссылка и QR PNG (base64)
+func (h *AdminHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	enrollment, err := h.service.EnrollTOTP(r.Context(), services.AdminFromContext(r.Context()).ID)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(enrollment)
+}
+
+// POST /admin/me/totp/confirm — включает 2FA и возвращает резервные коды
+func (h *AdminHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	codes, err := h.service.ConfirmTOTP(r.Context(), services.AdminFromContext(r.Context()).ID, req.Code)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"recovery_codes": codes})
+}
+
+// POST /admin/me/totp/disable
+func (h *AdminHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Password string `json:"password"`
+		OTP      string `json:"otp"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DisableTOTP(r.Context(), services.AdminFromContext(r.Context()).ID, req.Password, req.OTP); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PATCH /admin/admins/{id} (только суперадмин)
+func (h *AdminHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Rename(r.Context(), id, req.Username); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"username": req.Username})
+}
+
+// PUT /admin/admins/{id}/password (только суперадмин)
+func (h *AdminHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		NewPassword string `json:"new_password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.ResetPassword(r.Context(), id, req.NewPassword); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /admin/admins/{id}/disable (только суперадмин)
+func (h *AdminHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	h.setDisabled(w, r, true)
+}
+
+// POST /admin/admins/{id}/enable (только суперадмин)
+func (h *AdminHandler) Enable(w http.ResponseWriter, r *http.Request) {
+	h.setDisabled(w, r, false)
+}
+
+func (h *AdminHandler) setDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	actorID := services.AdminFromContext(r.Context()).ID
+	if err := h.service.SetDisabled(r.Context(), actorID, id, disabled); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{"disabled": disabled})
+}
+
+// POST /admin/login
+func (h *AdminHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		OTP      string `json:"otp"` // код TOTP или резервный код, если включена 2FA
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := h.service.Login(r.Context(), req.Username, req.Password, req.OTP)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+// POST /admin/refresh
+func (h *AdminHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	tokens, err := h.service.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+// POST /admin/logout
+func (h *AdminHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+		All          bool   `json:"all"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), req.RefreshToken, req.All); err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /admin/lockouts (только суперадмин)
+func (h *AdminHandler) ListLockouts(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 50
+	}
+
+	lockouts, total, err := h.service.ListLockouts(r.Context(), limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":   lockouts,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// DELETE /admin/lockouts?scope=username|ip&key=... (только суперадмин)
+func (h *AdminHandler) ClearLockout(w http.ResponseWriter, r *http.Request) {
+	scope := r.URL.Query().Get("scope")
+	key := r.URL.Query().Get("key")
+
+	if (scope != models.LoginScopeUsername && scope != models.LoginScopeIP) || key == "" {
+		http.Error(w, "scope (username or ip) and key are required", http.StatusBadRequest)
+		return
+	}
+
+	err := h.service.ClearLockout(r.Context(), scope, key)
+	if errors.Is(err, services.ErrLockoutNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Ошибки аутентификации отдаём как 401, блокировку — 429, остальное — 500
+func writeAuthError(w http.ResponseWriter, err error) {
+	var throttled *services.LoginThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		writeThrottled(w, throttled)
+	case errors.Is(err, services.ErrInvalidCredentials), errors.Is(err, services.ErrInvalidToken),
+		errors.Is(err, services.ErrOTPRequired), errors.Is(err, services.ErrInvalidOTP):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeThrottled(w http.ResponseWriter, err *services.LoginThrottledError) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(err.RetryAfter.Seconds())+1))
+	http.Error(w, err.Error(), http.StatusTooManyRequests)
+}
+
+// Ошибки управления аккаунтами
+func writeAdminError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrAdminNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, services.ErrInvalidCredentials), errors.Is(err, services.ErrInvalidOTP),
+		errors.Is(err, services.ErrOTPRequired):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, services.ErrUsernameTaken), errors.Is(err, services.ErrEmailTaken),
+		errors.Is(err, services.ErrLastSuperadmin),
+		errors.Is(err, services.ErrTOTPAlreadyEnabled), errors.Is(err, services.ErrTOTPNotPending):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, services.ErrCannotDisableSelf):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/services"
+)
+
+type AuditHandler struct {
+	service *services.AuditService
+}
+
+func NewAuditHandler(service *services.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// GET /admin/audit?actor_id=&action=&entity_type=&entity_id=&from=&to=&limit=&offset=
+// from/to — в формате RFC 3339
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+
+	if limit <= 0 {
+		limit = 50
+	}
+
+	filter := models.AuditFilter{
+		Action:     query.Get("action"),
+		EntityType: query.Get("entity_type"),
+	}
+
+	var err error
+	if v := query.Get("actor_id"); v != "" {
+		if filter.ActorID, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "Invalid actor_id", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get("entity_id"); v != "" {
+		if filter.EntityID, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "Invalid entity_id", http.StatusBadRequest)
+			return
+		}
+	}
+	if filter.From, err = parseTimeParam(query.Get("from")); err != nil {
+		http.Error(w, "Invalid from", http.StatusBadRequest)
+		return
+	}
+	if filter.To, err = parseTimeParam(query.Get("to")); err != nil {
+		http.Error(w, "Invalid to", http.StatusBadRequest)
+		return
+	}
+
+	entries, total, err := h.service.List(r.Context(), filter, limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":   entries,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// parseTimeParam: пустая строка — фильтр не задан
+func parseTimeParam(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+	t = t.UTC()
+	return &t, nil
+}
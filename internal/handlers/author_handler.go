@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/services"
+
+	"github.com/gorilla/mux"
+)
+
+type AuthorHandler struct {
+	service *services.AuthorService
+	news    *services.NewsService
+}
+
+func NewAuthorHandler(service *services.AuthorService, news *services.NewsService) *AuthorHandler {
+	return &AuthorHandler{service: service, news: news}
+}
+
+// Create author
+func (h *AuthorHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var author models.Author
+	if err := json.NewDecoder(r.Body).Decode(&author); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Create(r.Context(), &author); err != nil {
+		writeAuthorError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(author)
+}
+
+// List authors with published news counts (public endpoint), язык — ?lang= или Accept-Language
+func (h *AuthorHandler) List(w http.ResponseWriter, r *http.Request) {
+	language := requestLanguage(r, h.news)
+	authors, err := h.service.List(r.Context(), language)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if authors == nil {
+		authors = []*models.Author{}
+	}
+
+	setLanguageHeaders(w, language)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(authors)
+}
+
+// Profile — автор и его опубликованные новости (public endpoint): GET /authors/by-slug/{slug}?limit=&offset=
+func (h *AuthorHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	language := requestLanguage(r, h.news)
+	author, news, total, err := h.service.Profile(r.Context(), mux.Vars(r)["slug"], language, limit, offset)
+	if err != nil {
+		writeAuthorError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"author": author,
+		"data":   news,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	setLanguageHeaders(w, language)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Get author by ID with translations
+func (h *AuthorHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	author, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		writeAuthorError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(author)
+}
+
+// Update author
+func (h *AuthorHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var author models.Author
+	if err := json.NewDecoder(r.Body).Decode(&author); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	author.ID = id
+	if err := h.service.Update(r.Context(), &author); err != nil {
+		writeAuthorError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(author)
+}
+
+// Delete author
+func (h *AuthorHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		writeAuthorError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Save translation: PUT /authors/{id}/translations/{lang}
+func (h *AuthorHandler) SaveTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var translation models.AuthorTranslation
+	if err := json.NewDecoder(r.Body).Decode(&translation); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	translation.AuthorID = id
+	translation.Language = vars["lang"]
+	if err := h.service.SaveTranslation(r.Context(), &translation); err != nil {
+		writeAuthorError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translation)
+}
+
+// Delete translation: DELETE /authors/{id}/translations/{lang}
+func (h *AuthorHandler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteTranslation(r.Context(), id, vars["lang"]); err != nil {
+		writeAuthorError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Upload author photo
+func (h *AuthorHandler) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(10 << 20); err != nil { // максимум 10MB
+		http.Error(w, "File too large", http.StatusBadRequest)
+		return
+	}
+
+	file, handler, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Invalid file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	uploadDir := "./uploads/authors"
+	if err := os.MkdirAll(uploadDir, os.ModePerm); err != nil {
+		http.Error(w, "Cannot create upload dir", http.StatusInternalServerError)
+		return
+	}
+
+	filePath := filepath.Join(uploadDir, handler.Filename)
+	dst, err := os.Create(filePath)
+	if err != nil {
+		http.Error(w, "Cannot save file", http.StatusInternalServerError)
+		return
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, file); err != nil {
+		http.Error(w, "Cannot save file", http.StatusInternalServerError)
+		return
+	}
+
+	url := fmt.Sprintf("/uploads/authors/%s", handler.Filename)
+	if err := h.service.RecordUpload(r.Context(), url); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"url": url})
+}
+
+// Ошибки авторов: нет прав — 403, нет записи или перевода — 404,
+// занят slug или админ уже связан с другим автором — 409, остальное — 400
+func writeAuthorError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, services.ErrNotFound):
+		http.Error(w, "Author not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrTranslationNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, services.ErrAuthorTaken), errors.Is(err, services.ErrAdminLinked):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"monoex_backend/internal/config"
+	"monoex_backend/internal/feed"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/services"
+)
+
+// Сколько последних новостей попадает в ленту
+const feedSize = 50
+
+// FeedHandler отдаёт RSS и Atom по опубликованным новостям.
+// Фильтры те же, что у /news/published: ?category=, ?tag=, ?author=, ?lang= (или Accept-Language).
+type FeedHandler struct {
+	news *services.NewsService
+	site config.SiteConfig
+}
+
+func NewFeedHandler(news *services.NewsService, site config.SiteConfig) *FeedHandler {
+	return &FeedHandler{news: news, site: site}
+}
+
+// GET /news/feed.rss
+func (h *FeedHandler) RSS(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, feed.RSS, "application/rss+xml; charset=utf-8")
+}
+
+// GET /news/feed.atom
+func (h *FeedHandler) Atom(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, feed.Atom, "application/atom+xml; charset=utf-8")
+}
+
+// serve строит ленту; Last-Modified — последнее изменение новостей в ней
+func (h *FeedHandler) serve(w http.ResponseWriter, r *http.Request, render func(feed.Feed) ([]byte, error), contentType string) {
+	language := requestLanguage(r, h.news)
+	filter := models.NewsFilter{
+		Category: r.URL.Query().Get("category"),
+		Tag:      r.URL.Query().Get("tag"),
+		Author:   r.URL.Query().Get("author"),
+	}
+
+	news, err := h.news.GetPublished(r.Context(), filter, language, feedSize, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	f := h.build(r, news, filter, language)
+	body, err := render(f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	setLanguageHeaders(w, language)
+	serveXML(w, r, body, f.Updated, contentType)
+}
+
+// serveXML отдаёт документ через http.ServeContent: ETag — хэш тела,
+// If-None-Match/If-Modified-Since дают 304
+func serveXML(w http.ResponseWriter, r *http.Request, body []byte, modTime time.Time, contentType string) {
+	sum := sha256.Sum256(body)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	http.ServeContent(w, r, "", modTime, bytes.NewReader(body))
+}
+
+func (h *FeedHandler) build(r *http.Request, news []*models.News, filter models.NewsFilter, language string) feed.Feed {
+	title := h.site.Name
+	if filter.Category != "" {
+		title += " — " + filter.Category
+	}
+	if filter.Tag != "" {
+		title += " #" + filter.Tag
+	}
+	if filter.Author != "" {
+		title += " — " + filter.Author
+	}
+
+	f := feed.Feed{
+		Title:       title,
+		Link:        h.site.BaseURL + h.site.NewsPath,
+		Self:        requestBaseURL(r) + r.URL.RequestURI(),
+		Description: title,
+		Language:    language,
+		Updated:     time.Unix(0, 0).UTC(),
+	}
+
+	for _, n := range news {
+		link := h.site.BaseURL + h.site.NewsPath + url.PathEscape(n.Link)
+		// Плановая новость попадает в ленту по publish_at раньше, чем планировщик
+		// обновит updated_at, — лента считается изменённой не раньше даты публикации
+		published, updated := n.PublicationDate(), n.UpdatedAt
+		if updated.Before(published) {
+			updated = published
+		}
+		item := feed.Item{
+			Title:       n.Title,
+			Link:        link,
+			GUID:        link,
+			Description: n.Description,
+			Published:   published,
+			Updated:     updated,
+			Categories:  n.Categories,
+			Enclosure:   h.enclosure(r, n.ImagePath),
+		}
+		if updated.After(f.Updated) {
+			f.Updated = updated
+		}
+		f.Items = append(f.Items, item)
+	}
+	return f
+}
+
+// enclosure описывает изображение новости; размер берём с диска, если файл наш
+func (h *FeedHandler) enclosure(r *http.Request, imagePath string) *feed.Enclosure {
+	if imagePath == "" {
+		return nil
+	}
+
+	e := &feed.Enclosure{URL: imagePath, Type: mime.TypeByExtension(path.Ext(imagePath))}
+	if e.Type == "" {
+		e.Type = "application/octet-stream"
+	}
+	if strings.HasPrefix(imagePath, "/") {
+		e.URL = requestBaseURL(r) + imagePath
+	}
+	if strings.HasPrefix(imagePath, "/uploads/") && !strings.Contains(imagePath, "..") {
+		if info, err := os.Stat("." + imagePath); err == nil {
+			e.Length = info.Size()
+		}
+	}
+	return e
+}
+
+// requestBaseURL — схема и хост, по которым пришёл запрос (файлы /uploads раздаёт API)
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
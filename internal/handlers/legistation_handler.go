@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -31,6 +32,11 @@ func (h *LegislationHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Create(r.Context(), &legislation); err != nil {
+		if errors.Is(err, services.ErrInvalidCanonicalURL) || errors.Is(err, services.ErrInvalidOGImage) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		// логируем ошибку на сервере
 		fmt.Printf("❌ Failed to create legislation: %v\n", err)
 
@@ -116,6 +122,10 @@ func (h *LegislationHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	legislation.ID = id
 	if err := h.service.Update(r.Context(), &legislation); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			http.Error(w, "Legislation not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -134,6 +144,10 @@ func (h *LegislationHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			http.Error(w, "Legislation not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -175,9 +189,15 @@ func (h *LegislationHandler) UploadFile(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	url := "/uploads/legislation/" + fileName
+	if err := h.service.RecordUpload(r.Context(), url); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	// Возвращаем путь для фронта
 	response := map[string]string{
-		"url": "/uploads/legislation/" + fileName,
+		"url": url,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"monoex_backend/internal/models"
 	"monoex_backend/internal/services"
@@ -32,7 +36,7 @@ func (h *NewsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Create(r.Context(), &news); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeNewsError(w, err)
 		return
 	}
 
@@ -64,21 +68,36 @@ func (h *NewsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(news)
 }
 
-// Get news by link (public endpoint)
+// Get news by link (public endpoint), язык — ?lang= или Accept-Language
 func (h *NewsHandler) GetByLink(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	link := vars["link"]
 
-	news, err := h.service.GetByLink(r.Context(), link)
+	news, err := h.service.GetByLink(r.Context(), link, h.requestLanguage(r))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	if news == nil {
-		http.Error(w, "News not found", http.StatusNotFound)
+		// Ссылку могли переименовать — отправляем на текущую
+		current, err := h.service.CurrentLink(r.Context(), link)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if current == "" {
+			http.Error(w, "News not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Location", "/news/by-link/"+url.PathEscape(current))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMovedPermanently)
+		json.NewEncoder(w).Encode(map[string]string{"link": current})
 		return
 	}
 
+	setLanguageHeaders(w, news.Language)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(news)
 }
@@ -116,6 +135,8 @@ func (h *NewsHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 }
 
 // Get published news with pagination (public endpoint)
+// Фильтры: ?category=customs&tag=vat (slug'и), язык — ?lang= или Accept-Language.
+// ?pinned_first=true — закреплённые новости первыми, в ручном порядке.
 func (h *NewsHandler) GetPublished(w http.ResponseWriter, r *http.Request) {
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
@@ -124,13 +145,22 @@ func (h *NewsHandler) GetPublished(w http.ResponseWriter, r *http.Request) {
 		limit = 10
 	}
 
-	news, err := h.service.GetPublished(r.Context(), limit, offset)
+	pinnedFirst, _ := strconv.ParseBool(r.URL.Query().Get("pinned_first"))
+	filter := models.NewsFilter{
+		Category:    r.URL.Query().Get("category"),
+		Tag:         r.URL.Query().Get("tag"),
+		Author:      r.URL.Query().Get("author"),
+		PinnedFirst: pinnedFirst,
+	}
+
+	language := h.requestLanguage(r)
+	news, err := h.service.GetPublished(r.Context(), filter, language, limit, offset)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	total, err := h.service.GetPublishedCount(r.Context())
+	total, err := h.service.GetPublishedCount(r.Context(), filter)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -143,10 +173,71 @@ func (h *NewsHandler) GetPublished(w http.ResponseWriter, r *http.Request) {
 		"offset": offset,
 	}
 
+	setLanguageHeaders(w, language)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Search published news (public endpoint): ?q=&limit=&offset=
+func (h *NewsHandler) Search(w http.ResponseWriter, r *http.Request) {
+	limit, offset := searchPage(r)
+
+	results, total, err := h.service.Search(r.Context(), r.URL.Query().Get("q"), limit, offset)
+	if err != nil {
+		writeSearchError(w, err)
+		return
+	}
+
+	writeSearchResults(w, results, total, limit, offset)
+}
+
+// Search all news regardless of status (admin): ?q=&status=&limit=&offset=
+func (h *NewsHandler) SearchAll(w http.ResponseWriter, r *http.Request) {
+	limit, offset := searchPage(r)
+
+	results, total, err := h.service.SearchAll(r.Context(), r.URL.Query().Get("q"), r.URL.Query().Get("status"), limit, offset)
+	if err != nil {
+		writeSearchError(w, err)
+		return
+	}
+
+	writeSearchResults(w, results, total, limit, offset)
+}
+
+func searchPage(r *http.Request) (limit, offset int) {
+	limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ = strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 10
+	}
+	return limit, offset
+}
+
+func writeSearchResults(w http.ResponseWriter, results []*models.NewsSearchResult, total, limit, offset int) {
+	if results == nil {
+		results = []*models.NewsSearchResult{}
+	}
+
+	response := map[string]interface{}{
+		"data":   results,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+func writeSearchError(w http.ResponseWriter, err error) {
+	if errors.Is(err, services.ErrEmptyQuery) || errors.Is(err, services.ErrInvalidStatus) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
 // Publish news
 func (h *NewsHandler) Publish(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -156,17 +247,24 @@ func (h *NewsHandler) Publish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.UpdateStatus(r.Context(), id, "published"); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	h.setStatus(w, r, id, models.NewsPublished)
+}
+
+// Unpublish news
+func (h *NewsHandler) Unpublish(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "published"})
+	// Снятая с публикации новость возвращается в черновики
+	h.setStatus(w, r, id, models.NewsDraft)
 }
 
-// Unpublish news
-func (h *NewsHandler) Unpublish(w http.ResponseWriter, r *http.Request) {
+// Submit news for review (draft → in_review)
+func (h *NewsHandler) Submit(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
@@ -174,13 +272,29 @@ func (h *NewsHandler) Unpublish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.UpdateStatus(r.Context(), id, "not_published"); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	h.setStatus(w, r, id, models.NewsInReview)
+}
+
+// Archive news
+func (h *NewsHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	h.setStatus(w, r, id, models.NewsArchived)
+}
+
+func (h *NewsHandler) setStatus(w http.ResponseWriter, r *http.Request, id int, status string) {
+	if err := h.service.UpdateStatus(r.Context(), id, status); err != nil {
+		writeNewsError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "not_published"})
+	json.NewEncoder(w).Encode(map[string]string{"status": status})
 }
 
 // Update news
@@ -200,7 +314,7 @@ func (h *NewsHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	news.ID = id
 	if err := h.service.Update(r.Context(), &news); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeNewsError(w, err)
 		return
 	}
 
@@ -208,7 +322,8 @@ func (h *NewsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(news)
 }
 
-// Update news status (publish/unpublish)
+// Update news status: draft, in_review, published или archived
+// (переходы проверяет сервис, неразрешённый — 409)
 func (h *NewsHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
@@ -225,13 +340,40 @@ func (h *NewsHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.UpdateStatus(r.Context(), id, statusUpdate.Status); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	h.setStatus(w, r, id, statusUpdate.Status)
+}
+
+// Schedule news: автопубликация и автоснятие (null — без расписания)
+func (h *NewsHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var schedule struct {
+		PublishAt   *time.Time `json:"publish_at"`
+		UnpublishAt *time.Time `json:"unpublish_at"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Schedule(r.Context(), id, schedule.PublishAt, schedule.UnpublishAt); err != nil {
+		writeNewsError(w, err)
+		return
+	}
+
+	news, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "updated"})
+	json.NewEncoder(w).Encode(news)
 }
 
 // Delete news
@@ -244,6 +386,10 @@ func (h *NewsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			http.Error(w, "News not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -290,7 +436,27 @@ func (h *NewsHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
 
 	// Формирование URL (можно адаптировать под публичный путь)
 	url := fmt.Sprintf("/uploads/news/%s", handler.Filename)
+	if err := h.service.RecordUpload(r.Context(), url); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{"url": url})
 }
+
+// Ошибки сервиса новостей: нет прав — 403, нет записи — 404,
+// занята ссылка, недопустимый переход статуса или расписание не на ревью — 409, остальное — 400
+func writeNewsError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, services.ErrLinkTaken), errors.Is(err, services.ErrInvalidTransition),
+		errors.Is(err, services.ErrScheduleNeedsReview):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, services.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "News not found", http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
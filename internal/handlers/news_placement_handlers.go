@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"monoex_backend/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// Get featured news (public endpoint): GET /news/featured?limit=, язык — ?lang= или Accept-Language
+func (h *NewsHandler) GetFeatured(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	language := h.requestLanguage(r)
+	news, err := h.service.GetFeatured(r.Context(), language, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if news == nil {
+		news = []*models.News{}
+	}
+
+	setLanguageHeaders(w, language)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(news)
+}
+
+// Pin and feature news: PUT /news/{id}/placement {"pinned": true, "featured": true, "featured_until": null}
+func (h *NewsHandler) SetPlacement(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var placement models.NewsPlacement
+	if err := json.NewDecoder(r.Body).Decode(&placement); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.SetPlacement(r.Context(), id, placement); err != nil {
+		writeNewsError(w, err)
+		return
+	}
+
+	news, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(news)
+}
+
+// Reorder pinned and featured news: PUT /news/order {"ids": [5, 2, 9]} — сверху вниз
+func (h *NewsHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IDs []int `json:"ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Reorder(r.Context(), req.IDs); err != nil {
+		writeNewsError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
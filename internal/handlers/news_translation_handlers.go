@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/services"
+
+	"github.com/gorilla/mux"
+)
+
+// List translations of news with missing languages (admin)
+func (h *NewsHandler) ListTranslations(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	translations, missing, err := h.service.ListTranslations(r.Context(), id)
+	if err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data":    translations,
+		"missing": missing,
+	})
+}
+
+// Create or replace translation: PUT /news/{id}/translations/{lang}
+func (h *NewsHandler) SaveTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var translation models.NewsTranslation
+	if err := json.NewDecoder(r.Body).Decode(&translation); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	translation.NewsID = id
+	translation.Language = vars["lang"]
+	if err := h.service.SaveTranslation(r.Context(), &translation); err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translation)
+}
+
+// Delete translation: DELETE /news/{id}/translations/{lang}
+func (h *NewsHandler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteTranslation(r.Context(), id, vars["lang"]); err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeTranslationError(w http.ResponseWriter, err error) {
+	if errors.Is(err, services.ErrTranslationNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeNewsError(w, err)
+}
+
+func (h *NewsHandler) requestLanguage(r *http.Request) string {
+	return requestLanguage(r, h.service)
+}
+
+// requestLanguage выбирает язык ответа: ?lang=, затем Accept-Language,
+// затем резервный язык из конфига
+func requestLanguage(r *http.Request, news *services.NewsService) string {
+	supported := make(map[string]bool)
+	for _, lang := range news.Languages() {
+		supported[lang] = true
+	}
+
+	if lang := strings.ToLower(r.URL.Query().Get("lang")); supported[lang] {
+		return lang
+	}
+
+	// Accept-Language: "kk-KZ,ru;q=0.8,en;q=0.5" — берём поддерживаемый язык с наибольшим q
+	type weighted struct {
+		lang string
+		q    float64
+	}
+	var candidates []weighted
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !supported[lang] {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, weighted{lang, q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	if len(candidates) > 0 {
+		return candidates[0].lang
+	}
+
+	return news.FallbackLanguage()
+}
+
+// setLanguageHeaders: ответ зависит от Accept-Language, кэши должны это учитывать
+func setLanguageHeaders(w http.ResponseWriter, language string) {
+	w.Header().Add("Vary", "Accept-Language")
+	if language != "" {
+		w.Header().Set("Content-Language", language)
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"monoex_backend/internal/services"
+
+	"github.com/gorilla/mux"
+)
+
+type PreviewHandler struct {
+	service *services.PreviewService
+	news    *services.NewsService
+}
+
+func NewPreviewHandler(service *services.PreviewService, news *services.NewsService) *PreviewHandler {
+	return &PreviewHandler{service: service, news: news}
+}
+
+// Create preview link: POST /news/{id}/previews {"ttl_hours": 72}
+func (h *PreviewHandler) Create(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		TTLHours int `json:"ttl_hours"` // 0 — срок по умолчанию
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+	}
+
+	created, err := h.service.Create(r.Context(), id, time.Duration(req.TTLHours)*time.Hour)
+	if err != nil {
+		writePreviewError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// List preview links of news
+func (h *PreviewHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	previews, err := h.service.List(r.Context(), id)
+	if err != nil {
+		writePreviewError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(previews)
+}
+
+// Revoke preview link: DELETE /news/{id}/previews/{preview}
+func (h *PreviewHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), id, vars["preview"]); err != nil {
+		writePreviewError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Open preview (public endpoint): GET /news/preview/{token}, язык — ?lang= или Accept-Language
+func (h *PreviewHandler) Open(w http.ResponseWriter, r *http.Request) {
+	preview, err := h.service.Open(r.Context(), mux.Vars(r)["token"], requestLanguage(r, h.news))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidToken) {
+			http.Error(w, "Preview link is invalid, expired or revoked", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Черновик не должен попасть в кэши и поисковики
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+	setLanguageHeaders(w, preview.Language)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
+
+func writePreviewError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		http.Error(w, "News not found", http.StatusNotFound)
+	case errors.Is(err, services.ErrPreviewNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
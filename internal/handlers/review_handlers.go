@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"os"
@@ -125,6 +126,10 @@ func (h *ReviewHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	review.ID = id
 	if err := h.service.Update(r.Context(), &review); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			http.Error(w, "Review not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -143,6 +148,10 @@ func (h *ReviewHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			http.Error(w, "Review not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -177,8 +186,14 @@ func (h *ReviewHandler) UploadFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	url := "/uploads/reviews/" + handler.Filename
+	if err := h.service.RecordUpload(r.Context(), url); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]string{
-		"url": "/uploads/reviews/" + handler.Filename,
+		"url": url,
 	})
 }
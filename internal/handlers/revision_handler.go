@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"monoex_backend/internal/services"
+
+	"github.com/gorilla/mux"
+)
+
+// RevisionHandler — история ревизий одной сущности (новости или законы).
+// restore восстанавливает ревизию через сервис сущности и возвращает новое состояние.
+type RevisionHandler struct {
+	service    *services.RevisionService
+	entityType string
+	restore    func(ctx context.Context, id, revision int) (any, error)
+}
+
+func NewRevisionHandler(service *services.RevisionService, entityType string, restore func(ctx context.Context, id, revision int) (any, error)) *RevisionHandler {
+	return &RevisionHandler{service: service, entityType: entityType, restore: restore}
+}
+
+// GET /{entity}/{id}/revisions
+func (h *RevisionHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 20
+	}
+
+	revisions, total, err := h.service.List(r.Context(), h.entityType, id, limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":   revisions,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GET /{entity}/{id}/revisions/{revision}
+func (h *RevisionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, revision, ok := revisionVars(w, r)
+	if !ok {
+		return
+	}
+
+	rev, err := h.service.Get(r.Context(), h.entityType, id, revision)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rev)
+}
+
+// GET /{entity}/{id}/revisions/diff?from=&to= (по умолчанию — последняя против предыдущей)
+func (h *RevisionHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	from, _ := strconv.Atoi(r.URL.Query().Get("from"))
+	to, _ := strconv.Atoi(r.URL.Query().Get("to"))
+
+	diff, err := h.service.Diff(r.Context(), h.entityType, id, from, to)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diff)
+}
+
+// POST /{entity}/{id}/revisions/{revision}/restore — старое содержимое сохраняется как новая ревизия
+func (h *RevisionHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id, revision, ok := revisionVars(w, r)
+	if !ok {
+		return
+	}
+
+	restored, err := h.restore(r.Context(), id, revision)
+	if err != nil {
+		writeRevisionError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(restored)
+}
+
+func revisionVars(w http.ResponseWriter, r *http.Request) (id, revision int, ok bool) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	revision, err = strconv.Atoi(vars["revision"])
+	if err != nil {
+		http.Error(w, "Invalid revision", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return id, revision, true
+}
+
+func writeRevisionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrRevisionNotFound), errors.Is(err, services.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, services.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, services.ErrLinkTaken), errors.Is(err, services.ErrInvalidTransition):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
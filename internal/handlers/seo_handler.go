@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"monoex_backend/internal/config"
+	"monoex_backend/internal/seo"
+	"monoex_backend/internal/services"
+
+	"github.com/gorilla/mux"
+)
+
+// Длина описания, которое берём из текста записи, если описания нет
+const seoDescriptionLength = 160
+
+// SEOHandler отдаёт готовые теги Open Graph / Twitter Card и JSON-LD для страниц сайта.
+// Пустые SEO-поля записи заменяются заголовком, описанием, страницей на сайте и изображением.
+type SEOHandler struct {
+	news        *services.NewsService
+	legislation *services.LegislationService
+	site        config.SiteConfig
+}
+
+func NewSEOHandler(news *services.NewsService, legislation *services.LegislationService, site config.SiteConfig) *SEOHandler {
+	return &SEOHandler{news: news, legislation: legislation, site: site}
+}
+
+// News metadata (public endpoint): GET /seo/news/{link}, язык — ?lang= или Accept-Language
+func (h *SEOHandler) News(w http.ResponseWriter, r *http.Request) {
+	link := mux.Vars(r)["link"]
+
+	news, err := h.news.GetByLink(r.Context(), link, requestLanguage(r, h.news))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if news == nil {
+		// Ссылку могли переименовать — отправляем на текущую
+		current, err := h.news.CurrentLink(r.Context(), link)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if current == "" {
+			http.Error(w, "News not found", http.StatusNotFound)
+			return
+		}
+		http.Redirect(w, r, "/seo/news/"+url.PathEscape(current), http.StatusMovedPermanently)
+		return
+	}
+
+	// Дата публикации — фактическая (published_at); изменение не может быть раньше неё
+	published, modified := news.PublicationDate(), news.UpdatedAt
+	if modified.Before(published) {
+		modified = published
+	}
+
+	alternates := make(map[string]string, len(news.Alternates))
+	for lang, l := range news.Alternates {
+		alternates[lang] = h.site.BaseURL + h.site.NewsPath + l
+	}
+
+	h.write(w, seo.Page{
+		Type:        seo.TypeNewsArticle,
+		Title:       firstNonEmpty(news.MetaTitle, news.Title),
+		Description: firstNonEmpty(news.MetaDescription, news.Description, seo.Truncate(news.FullText, seoDescriptionLength)),
+		URL:         firstNonEmpty(news.CanonicalURL, h.site.BaseURL+h.site.NewsPath+news.Link),
+		Image:       absoluteURL(r, firstNonEmpty(news.OGImage, news.ImagePath)),
+		Language:    news.Language,
+		SiteName:    h.site.Name,
+		SiteURL:     h.site.BaseURL,
+		Published:   published,
+		Modified:    modified,
+		Sections:    news.Categories,
+		Keywords:    news.Tags,
+		Alternates:  alternates,
+	}, news.Language)
+}
+
+// Legislation metadata: GET /legislations/{id}/seo
+func (h *SEOHandler) Legislation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	l, err := h.legislation.GetByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if l == nil {
+		http.Error(w, "Legislation not found", http.StatusNotFound)
+		return
+	}
+
+	h.write(w, seo.Page{
+		Type:        seo.TypeLegislation,
+		Title:       firstNonEmpty(l.MetaTitle, l.Title),
+		Description: firstNonEmpty(l.MetaDescription, seo.Truncate(l.Description, seoDescriptionLength)),
+		URL:         firstNonEmpty(l.CanonicalURL, h.site.BaseURL+h.site.LegislationPath+strconv.Itoa(l.ID)),
+		Image:       absoluteURL(r, l.OGImage),
+		Language:    h.news.DefaultLanguage(),
+		SiteName:    h.site.Name,
+		SiteURL:     h.site.BaseURL,
+		Published:   l.CreatedAt,
+		Modified:    l.UpdatedAt,
+		Document:    absoluteURL(r, l.FilePath),
+	}, "")
+}
+
+func (h *SEOHandler) write(w http.ResponseWriter, page seo.Page, language string) {
+	meta, err := seo.Build(page)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if language != "" {
+		setLanguageHeaders(w, language)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(meta)
+}
+
+// absoluteURL дополняет путь вида /uploads/... адресом API, который раздаёт файлы
+func absoluteURL(r *http.Request, path string) string {
+	if strings.HasPrefix(path, "/") {
+		return requestBaseURL(r) + path
+	}
+	return path
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"monoex_backend/internal/config"
+	"monoex_backend/internal/services"
+	"monoex_backend/internal/sitemap"
+
+	"github.com/gorilla/mux"
+)
+
+// SitemapHandler отдаёт sitemap index и файлы sitemap с опубликованными новостями
+// и их переводами. Законы и отзывы в API доступны только админам, поэтому в sitemap их нет.
+// Адреса — от site.base_url: фронт проксирует /sitemap.xml и /sitemaps/ на бэкенд.
+type SitemapHandler struct {
+	news *services.NewsService
+	site config.SiteConfig
+}
+
+func NewSitemapHandler(news *services.NewsService, site config.SiteConfig) *SitemapHandler {
+	return &SitemapHandler{news: news, site: site}
+}
+
+// GET /sitemap.xml — индекс файлов /sitemaps/news-N.xml
+func (h *SitemapHandler) Index(w http.ResponseWriter, r *http.Request) {
+	pages, err := h.news.SitemapPages(r.Context(), sitemap.MaxURLs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Даже без новостей индекс ссылается на один (пустой) файл
+	if len(pages) == 0 {
+		pages = []time.Time{{}}
+	}
+
+	var modTime time.Time
+	files := make([]sitemap.URL, len(pages))
+	for i, lastMod := range pages {
+		files[i] = sitemap.URL{
+			Loc:     fmt.Sprintf("%s/sitemaps/news-%d.xml", h.site.BaseURL, i+1),
+			LastMod: lastMod,
+		}
+		if lastMod.After(modTime) {
+			modTime = lastMod
+		}
+	}
+
+	body, err := sitemap.Index(files)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	serveXML(w, r, body, modTime, "application/xml; charset=utf-8")
+}
+
+// GET /sitemaps/news-{page}.xml
+func (h *SitemapHandler) News(w http.ResponseWriter, r *http.Request) {
+	page, err := strconv.Atoi(mux.Vars(r)["page"])
+	if err != nil || page < 1 {
+		http.Error(w, "Sitemap not found", http.StatusNotFound)
+		return
+	}
+
+	links, err := h.news.SitemapLinks(r.Context(), page, sitemap.MaxURLs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(links) == 0 && page > 1 {
+		http.Error(w, "Sitemap not found", http.StatusNotFound)
+		return
+	}
+
+	var modTime time.Time
+	urls := make([]sitemap.URL, len(links))
+	for i, l := range links {
+		urls[i] = sitemap.URL{
+			Loc:     h.site.BaseURL + h.site.NewsPath + url.PathEscape(l.Link),
+			LastMod: l.UpdatedAt,
+		}
+		if l.UpdatedAt.After(modTime) {
+			modTime = l.UpdatedAt
+		}
+	}
+
+	body, err := sitemap.URLSet(urls)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	serveXML(w, r, body, modTime, "application/xml; charset=utf-8")
+}
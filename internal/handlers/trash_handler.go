@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"monoex_backend/internal/services"
+
+	"github.com/gorilla/mux"
+)
+
+type TrashHandler struct {
+	service *services.TrashService
+}
+
+func NewTrashHandler(service *services.TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// List trash with pagination: GET /trash?type=news|legislation|review
+// Без type — все типы, которые админ может читать
+func (h *TrashHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	items, total, err := h.service.List(r.Context(), r.URL.Query().Get("type"), limit, offset)
+	if err != nil {
+		writeTrashError(w, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"data":   items,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// Restore from trash: POST /trash/{type}/{id}/restore
+func (h *TrashHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Restore(r.Context(), vars["type"], id); err != nil {
+		writeTrashError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Purge permanently, with the uploaded file: DELETE /trash/{type}/{id}
+func (h *TrashHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Purge(r.Context(), vars["type"], id); err != nil {
+		writeTrashError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeTrashError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case errors.Is(err, services.ErrNotFound):
+		http.Error(w, "Not found in trash", http.StatusNotFound)
+	case errors.Is(err, services.ErrUnknownContentType):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
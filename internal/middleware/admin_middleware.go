@@ -1,28 +1,95 @@
 package middleware
 
 import (
+	"errors"
+	"monoex_backend/internal/models"
 	"monoex_backend/internal/services"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
-// AdminMiddleware проверяет BasicAuth и админские права
+// AdminMiddleware проверяет API-ключ (X-API-Key), Bearer-токен или BasicAuth
+// на переходный период (с кодом 2FA в заголовке X-OTP) и наличие права perm,
+// которое требует маршрут: у людей — по роли, у ключей — по scopes.
+// Пустой perm — маршрут доступен любому админу, права проверяет сервис.
 // Принимает обычную функцию хэндлера и возвращает http.HandlerFunc
-func AdminMiddleware(service *services.AdminService) func(func(http.ResponseWriter, *http.Request)) http.HandlerFunc {
+func AdminMiddleware(service *services.AdminService, perm models.Permission) func(func(http.ResponseWriter, *http.Request)) http.HandlerFunc {
 	return func(next func(http.ResponseWriter, *http.Request)) http.HandlerFunc {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if key := r.Header.Get("X-API-Key"); key != "" {
+				admin, err := service.AuthenticateAPIKey(r.Context(), key)
+				if errors.Is(err, services.ErrInvalidToken) {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				serveAdmin(w, r, admin, perm, next)
+				return
+			}
+
+			if token, ok := bearerToken(r); ok {
+				admin, err := service.AuthenticateToken(r.Context(), token)
+				if errors.Is(err, services.ErrInvalidToken) {
+					w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				serveAdmin(w, r, admin, perm, next)
+				return
+			}
+
 			username, password, ok := r.BasicAuth()
 			if !ok {
-				w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
+				w.Header().Set("WWW-Authenticate", `Bearer, Basic realm="Restricted"`)
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
 
-			if !service.ValidateAdmin(username, password) {
+			admin, err := service.ValidateAdmin(r.Context(), username, password, r.Header.Get("X-OTP"))
+			var throttled *services.LoginThrottledError
+			if errors.As(err, &throttled) {
+				w.Header().Set("Retry-After", strconv.Itoa(int(throttled.RetryAfter.Seconds())+1))
+				http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+				return
+			}
+			if errors.Is(err, services.ErrOTPRequired) {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
 				http.Error(w, "Forbidden", http.StatusForbidden)
 				return
 			}
 
-			next(w, r)
+			serveAdmin(w, r, admin, perm, next)
 		})
 	}
 }
+
+// serveAdmin проверяет право и передаёт админа дальше через контекст
+func serveAdmin(w http.ResponseWriter, r *http.Request, admin *models.Admin, perm models.Permission, next func(http.ResponseWriter, *http.Request)) {
+	if perm != "" && !admin.Can(perm) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	next(w, r.WithContext(services.WithAdmin(r.Context(), admin)))
+}
+
+// bearerToken достаёт токен из заголовка "Authorization: Bearer <token>"
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
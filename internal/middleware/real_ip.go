@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"monoex_backend/internal/services"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RealIP определяет IP клиента и кладёт его в контекст запроса.
+// Заголовкам X-Forwarded-For/X-Real-IP верим только за доверенным прокси,
+// иначе клиент мог бы подставить любой адрес.
+func RealIP(trustProxy bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := remoteHost(r.RemoteAddr)
+			if trustProxy {
+				if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+					first, _, _ := strings.Cut(forwarded, ",")
+					ip = strings.TrimSpace(first)
+				} else if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+					ip = strings.TrimSpace(realIP)
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(services.WithClientIP(r.Context(), ip)))
+		})
+	}
+}
+
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
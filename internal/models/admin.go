@@ -1,7 +1,58 @@
 package models
 
+import "time"
+
 type Admin struct {
-	ID       int    `db:"id"`
-	Username string `db:"username"`
-	Password string `db:"password"` // хранить хэш
+	ID         int        `json:"id" db:"id"`
+	Username   string     `json:"username" db:"username"`
+	Email      string     `json:"email" db:"email"` // для сброса пароля; может быть пустым
+	Password   string     `json:"-" db:"password"`  // хранить хэш
+	Role       string     `json:"role" db:"role"`   // superadmin/publisher/editor
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+	DisabledAt *time.Time `json:"disabled_at" db:"disabled_at"` // nil — аккаунт активен
+
+	TOTPSecret  string `json:"-" db:"totp_secret"` // base32; до подтверждения — ожидающий
+	TOTPEnabled bool   `json:"totp_enabled" db:"totp_enabled"`
+
+	// Заполняются, когда запрос пришёл с API-ключом: права ограничены его scopes
+	APIKeyID int          `json:"-"`
+	Scopes   []Permission `json:"-"`
+}
+
+// Can проверяет право: для людей — по роли, для API-ключа — по scopes
+func (a *Admin) Can(perm Permission) bool {
+	if a.APIKeyID != 0 {
+		for _, scope := range a.Scopes {
+			if scope == perm {
+				return true
+			}
+		}
+		return false
+	}
+	return RoleHasPermission(a.Role, perm)
+}
+
+// Ответ на начало подключения TOTP
+type TOTPEnrollment struct {
+	Secret     string `json:"secret"`      // base32 для ручного ввода
+	OTPAuthURI string `json:"otpauth_uri"` // otpauth://totp/...
+	QRCodePNG  []byte `json:"qr_png"`      // PNG, в JSON — base64
+}
+
+// Пара токенов, выдаваемая при логине и refresh
+type AdminTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"` // в секундах
+}
+
+// Серверная запись refresh-токена (для отзыва)
+type RefreshToken struct {
+	ID        string     `db:"id"`
+	AdminID   int        `db:"admin_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
 }
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Ключ для машинных клиентов (импорт, сборка статики). Сам ключ не хранится — только хэш.
+type APIKey struct {
+	ID         int          `json:"id" db:"id"`
+	Name       string       `json:"name" db:"name"`
+	Prefix     string       `json:"prefix" db:"prefix"` // видимая часть ключа, по ней ищем
+	Scopes     []Permission `json:"scopes" db:"scopes"`
+	CreatedBy  *int         `json:"created_by" db:"created_by"`
+	ExpiresAt  *time.Time   `json:"expires_at" db:"expires_at"`
+	LastUsedAt *time.Time   `json:"last_used_at" db:"last_used_at"`
+	RevokedAt  *time.Time   `json:"revoked_at" db:"revoked_at"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+
+	KeyHash string `json:"-" db:"key_hash"`
+}
+
+// Ответ на создание: полный ключ показывается один раз
+type APIKeyCreated struct {
+	*APIKey
+	Key string `json:"key"`
+}
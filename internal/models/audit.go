@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Действия, попадающие в журнал аудита
+const (
+	AuditCreate    = "create"
+	AuditUpdate    = "update"
+	AuditDelete    = "delete"
+	AuditPublish   = "publish"
+	AuditUnpublish = "unpublish"
+	AuditUpload    = "upload"
+	AuditShare     = "share"   // выдана ссылка предпросмотра
+	AuditRevoke    = "revoke"  // ссылка предпросмотра отозвана
+	AuditRestore   = "restore" // запись возвращена из корзины
+	AuditPurge     = "purge"   // запись удалена из корзины окончательно
+)
+
+// Типы сущностей в журнале аудита
+const (
+	EntityNews        = "news"
+	EntityLegislation = "legislation"
+	EntityReview      = "review"
+	EntityFile        = "file"
+	EntityCategory    = "category"
+	EntityAuthor      = "author"
+)
+
+type AuditEntry struct {
+	ID         int64           `json:"id" db:"id"`
+	ActorID    *int            `json:"actor_id" db:"actor_id"` // nil — система (планировщик и т.п.)
+	ActorName  string          `json:"actor_name" db:"actor_name"`
+	APIKeyID   *int            `json:"api_key_id" db:"api_key_id"`
+	Action     string          `json:"action" db:"action"`
+	EntityType string          `json:"entity_type" db:"entity_type"`
+	EntityID   *int            `json:"entity_id" db:"entity_id"`
+	Before     json.RawMessage `json:"before" db:"before"`
+	After      json.RawMessage `json:"after" db:"after"`
+	IP         string          `json:"ip" db:"ip"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Фильтр для /admin/audit (пустые поля не учитываются)
+type AuditFilter struct {
+	ActorID    int
+	Action     string
+	EntityType string
+	EntityID   int
+	From       *time.Time
+	To         *time.Time
+}
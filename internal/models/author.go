@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Author — автор публикаций. Поля — на языке по умолчанию, остальные языки — в переводах.
+type Author struct {
+	ID             int                           `json:"id" db:"id"`
+	Slug           string                        `json:"slug" db:"slug"`
+	Name           string                        `json:"name" db:"name"`
+	Position       string                        `json:"position" db:"position"` // должность
+	PhotoPath      string                        `json:"photo_path" db:"photo_path"`
+	Bio            string                        `json:"bio" db:"bio"`
+	AdminID        *int                          `json:"admin_id,omitempty" db:"admin_id"` // связанный аккаунт админа (в публичных ответах не отдаётся)
+	Language       string                        `json:"language,omitempty" db:"-"`        // язык содержимого в публичном ответе
+	Translations   map[string]*AuthorTranslation `json:"translations,omitempty" db:"-"`    // язык → перевод (для админки)
+	PublishedCount int                           `json:"published_count" db:"-"`           // опубликованных новостей автора
+	CreatedAt      time.Time                     `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time                     `json:"updated_at" db:"updated_at"`
+}
+
+// AuthorTranslation — имя, должность и биография автора на языке, отличном от языка по умолчанию
+type AuthorTranslation struct {
+	AuthorID  int       `json:"-" db:"author_id"`
+	Language  string    `json:"language" db:"language"`
+	Name      string    `json:"name" db:"name"`
+	Position  string    `json:"position" db:"position"`
+	Bio       string    `json:"bio" db:"bio"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Byline — автор в подписи новости, на языке новости
+type Byline struct {
+	ID        int    `json:"id"`
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	Position  string `json:"position"`
+	PhotoPath string `json:"photo_path"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Category — рубрика новостей ("Таможня", "Налоги")
+type Category struct {
+	ID             int       `json:"id" db:"id"`
+	Slug           string    `json:"slug" db:"slug"`
+	Name           string    `json:"name" db:"name"`
+	Description    string    `json:"description" db:"description"`
+	PublishedCount int       `json:"published_count" db:"-"` // опубликованных новостей в рубрике
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Tag — свободная метка новости; создаётся при первом назначении
+type Tag struct {
+	ID   int    `json:"id" db:"id"`
+	Slug string `json:"slug" db:"slug"`
+	Name string `json:"name" db:"name"`
+}
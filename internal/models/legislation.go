@@ -9,4 +9,6 @@ type Legislation struct {
 	FilePath    string    `json:"file_path" db:"file_path"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	SEOFields
 }
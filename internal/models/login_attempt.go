@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+const (
+	LoginScopeUsername = "username"
+	LoginScopeIP       = "ip"
+)
+
+// Счётчик неудачных входов по логину или IP
+type LoginAttempt struct {
+	Scope         string     `json:"scope" db:"scope"` // username/ip
+	Key           string     `json:"key" db:"key"`
+	Failures      int        `json:"failures" db:"failures"`
+	LastFailureAt time.Time  `json:"last_failure_at" db:"last_failure_at"`
+	BlockedUntil  *time.Time `json:"blocked_until" db:"blocked_until"`
+}
@@ -3,13 +3,108 @@ package models
 import "time"
 
 type News struct {
-	ID          int       `json:"id" db:"id"`
-	Title       string    `json:"title" db:"title"`
-	Description string    `json:"description" db:"description"`
-	FullText    string    `json:"full_text" db:"full_text"`
-	ImagePath   string    `json:"image_path" db:"image_path"`
-	Status      string    `json:"status" db:"status"` // published/not_published
-	Link        string    `json:"link" db:"link"`     // автогенерация
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID               int               `json:"id" db:"id"`
+	Title            string            `json:"title" db:"title"`
+	Description      string            `json:"description" db:"description"`
+	FullText         string            `json:"full_text" db:"full_text"`
+	ImagePath        string            `json:"image_path" db:"image_path"`
+	Status           string            `json:"status" db:"status"`                 // draft/in_review/published/archived
+	Link             string            `json:"link" db:"link"`                     // автогенерация
+	PublishAt        *time.Time        `json:"publish_at" db:"publish_at"`         // опубликовать автоматически (UTC)
+	UnpublishAt      *time.Time        `json:"unpublish_at" db:"unpublish_at"`     // снять с публикации автоматически (UTC)
+	PublishedAt      *time.Time        `json:"published_at" db:"published_at"`     // когда новость фактически опубликована
+	Pinned           bool              `json:"pinned" db:"pinned"`                 // закреплена вверху ленты
+	Featured         bool              `json:"featured" db:"featured"`             // в блоке «главное»
+	FeaturedUntil    *time.Time        `json:"featured_until" db:"featured_until"` // конец показа в «главном» (UTC), nil — бессрочно
+	Priority         int               `json:"priority" db:"priority"`             // ручной порядок закреплённых и избранных, меньше — выше
+	Categories       []string          `json:"categories" db:"-"`                  // slug'и рубрик
+	Tags             []string          `json:"tags" db:"-"`                        // slug'и тегов
+	Authors          []string          `json:"authors" db:"-"`                     // slug'и авторов в порядке подписи
+	Bylines          []Byline          `json:"bylines" db:"-"`                     // авторы для подписи (только чтение)
+	Language         string            `json:"language,omitempty" db:"-"`          // язык содержимого в публичном ответе
+	Alternates       map[string]string `json:"alternates,omitempty" db:"-"`        // язык → ссылка опубликованных версий
+	MissingLanguages []string          `json:"missing_languages,omitempty" db:"-"` // языки без перевода (для админки)
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at" db:"updated_at"`
+
+	SEOFields
+}
+
+// PublicationDate — дата публикации для лент и метаданных: фактическое время публикации,
+// а пока планировщик не сменил статус — плановое, иначе время создания
+func (n *News) PublicationDate() time.Time {
+	if n.PublishedAt != nil {
+		return *n.PublishedAt
+	}
+	if n.PublishAt != nil {
+		return *n.PublishAt
+	}
+	return n.CreatedAt
+}
+
+// NewsPage — публичная страница новости или её перевода (для sitemap)
+type NewsPage struct {
+	Link      string
+	UpdatedAt time.Time
+}
+
+// Фильтр публичной ленты (пустые поля не учитываются)
+type NewsFilter struct {
+	Category    string // slug рубрики
+	Tag         string // slug тега
+	Author      string // slug автора
+	PinnedFirst bool   // закреплённые — первыми, в порядке priority
+}
+
+// NewsPlacement — закрепление и показ новости в «главном»
+type NewsPlacement struct {
+	Pinned        bool       `json:"pinned"`
+	Featured      bool       `json:"featured"`
+	FeaturedUntil *time.Time `json:"featured_until"`
+}
+
+// Фильтр поиска: Published — только видимые на сайте в этот момент, Status — только с этим статусом
+type NewsSearchFilter struct {
+	Published *time.Time
+	Status    string
+}
+
+// NewsSearchResult — найденная новость с релевантностью и подсветкой (<mark>…</mark>)
+type NewsSearchResult struct {
+	*News
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+// Статусы новости
+const (
+	NewsDraft     = "draft"
+	NewsInReview  = "in_review"
+	NewsPublished = "published"
+	NewsArchived  = "archived"
+)
+
+// Разрешённые переходы между статусами
+var newsTransitions = map[string][]string{
+	NewsDraft:     {NewsInReview, NewsPublished, NewsArchived},
+	NewsInReview:  {NewsDraft, NewsPublished, NewsArchived},
+	NewsPublished: {NewsDraft, NewsArchived},
+	NewsArchived:  {NewsDraft},
+}
+
+// IsValidNewsStatus проверяет, что статус известен
+func IsValidNewsStatus(status string) bool {
+	_, ok := newsTransitions[status]
+	return ok
+}
+
+// CanTransitionNews проверяет, разрешён ли переход from → to
+func CanTransitionNews(from, to string) bool {
+	for _, next := range newsTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
 }
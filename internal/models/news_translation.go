@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// NewsTranslation — перевод новости на язык, отличный от языка по умолчанию
+type NewsTranslation struct {
+	NewsID      int       `json:"news_id" db:"news_id"`
+	Language    string    `json:"language" db:"language"` // ru, kk, en
+	Title       string    `json:"title" db:"title"`
+	Description string    `json:"description" db:"description"`
+	FullText    string    `json:"full_text" db:"full_text"`
+	Link        string    `json:"link" db:"link"`           // своя ссылка; пусто — из заголовка
+	Published   bool      `json:"published" db:"published"` // показывать ли перевод на сайте
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
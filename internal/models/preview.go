@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// PreviewToken — выданная ссылка предпросмотра новости. Сам токен не хранится.
+type PreviewToken struct {
+	ID        string     `json:"id" db:"id"` // jti токена
+	NewsID    int        `json:"news_id" db:"news_id"`
+	CreatedBy *int       `json:"created_by" db:"created_by"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at" db:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Ответ на создание: токен показывается один раз
+type PreviewTokenCreated struct {
+	*PreviewToken
+	Token string `json:"token"`
+	URL   string `json:"url"` // публичный адрес предпросмотра в API
+}
+
+// NewsPreview — новость в публичном предпросмотре, с пометкой preview
+type NewsPreview struct {
+	*News
+	Preview          bool      `json:"preview"`
+	PreviewExpiresAt time.Time `json:"preview_expires_at"`
+}
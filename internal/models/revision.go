@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Revision — неизменяемый снимок новости или закона на момент сохранения
+type Revision struct {
+	ID         int64           `json:"id" db:"id"`
+	EntityType string          `json:"entity_type" db:"entity_type"` // news/legislation
+	EntityID   int             `json:"entity_id" db:"entity_id"`
+	Revision   int             `json:"revision" db:"revision"` // 1, 2, 3… в пределах записи
+	AuthorID   *int            `json:"author_id" db:"author_id"`
+	AuthorName string          `json:"author_name" db:"author_name"`
+	Content    json.RawMessage `json:"content,omitempty" db:"content"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// FieldChange — изменение одного поля между двумя ревизиями
+type FieldChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+type RevisionDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
@@ -0,0 +1,76 @@
+package models
+
+// Роли админских аккаунтов
+const (
+	RoleSuperadmin = "superadmin" // управляет аккаунтами, может всё
+	RolePublisher  = "publisher"  // редактор + публикация новостей
+	RoleEditor     = "editor"     // создание и редактирование контента
+)
+
+// Permission — право на конкретное действие, которое требует маршрут
+type Permission string
+
+const (
+	PermNewsRead         Permission = "news:read"
+	PermNewsWrite        Permission = "news:write"
+	PermNewsPublish      Permission = "news:publish"
+	PermLegislationRead  Permission = "legislation:read"
+	PermLegislationWrite Permission = "legislation:write"
+	PermReviewsRead      Permission = "reviews:read"
+	PermReviewsWrite     Permission = "reviews:write"
+	PermFilesUpload      Permission = "files:upload"
+	PermAdminsManage     Permission = "admins:manage"
+	PermAuditRead        Permission = "audit:read"
+	PermOwnAccount       Permission = "account:own" // свой профиль и пароль
+)
+
+var editorPermissions = []Permission{
+	PermNewsRead, PermNewsWrite,
+	PermLegislationRead, PermLegislationWrite,
+	PermReviewsRead, PermReviewsWrite,
+	PermFilesUpload,
+	PermOwnAccount,
+}
+
+var rolePermissions = map[string][]Permission{
+	RoleEditor:    editorPermissions,
+	RolePublisher: append(append([]Permission{}, editorPermissions...), PermNewsPublish),
+	RoleSuperadmin: append(append([]Permission{}, editorPermissions...),
+		PermNewsPublish, PermAdminsManage, PermAuditRead),
+}
+
+// Права, которые можно выдать API-ключу. Управление аккаунтами и
+// собственный профиль доступны только людям.
+var APIKeyScopes = []Permission{
+	PermNewsRead, PermNewsWrite, PermNewsPublish,
+	PermLegislationRead, PermLegislationWrite,
+	PermReviewsRead, PermReviewsWrite,
+	PermFilesUpload,
+	PermAuditRead,
+}
+
+// IsValidRole проверяет, что роль известна
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// RoleHasPermission проверяет, входит ли право в роль
+func RoleHasPermission(role string, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAPIKeyScope проверяет, что право можно выдать API-ключу
+func IsAPIKeyScope(perm Permission) bool {
+	for _, p := range APIKeyScopes {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
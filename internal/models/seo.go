@@ -0,0 +1,10 @@
+package models
+
+// SEOFields — SEO-поля новости или закона. Пустое поле — значение по умолчанию:
+// заголовок, описание, страница на сайте и изображение самой записи.
+type SEOFields struct {
+	MetaTitle       string `json:"meta_title" db:"meta_title"`
+	MetaDescription string `json:"meta_description" db:"meta_description"`
+	CanonicalURL    string `json:"canonical_url" db:"canonical_url"` // абсолютный http(s) URL
+	OGImage         string `json:"og_image" db:"og_image"`           // абсолютный URL или /uploads/...
+}
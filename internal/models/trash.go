@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// TrashItem — удалённая новость, закон или отзыв в корзине
+type TrashItem struct {
+	EntityType string    `json:"entity_type" db:"entity_type"` // news/legislation/review
+	ID         int       `json:"id" db:"id"`
+	Title      string    `json:"title" db:"title"`         // у отзыва — название компании
+	FilePath   string    `json:"file_path" db:"file_path"` // изображение или PDF, удаляется вместе с записью
+	DeletedAt  time.Time `json:"deleted_at" db:"deleted_at"`
+	PurgeAt    time.Time `json:"purge_at" db:"-"` // когда запись удалится окончательно
+}
@@ -0,0 +1,141 @@
+package qrcode
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Reed–Solomon: примеры уровня M версии 1 из ISO/IEC 18004 (приложение I) и thonky.com
+func TestRSRemainder(t *testing.T) {
+	tests := []struct {
+		name      string
+		data, ecc []byte
+	}{
+		{
+			name: "numeric 01234567",
+			data: []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11},
+			ecc:  []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55},
+		},
+		{
+			name: "alphanumeric HELLO WORLD",
+			data: []byte{0x20, 0x5B, 0x0B, 0x78, 0xD1, 0x72, 0xDC, 0x4D, 0x43, 0x40, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11},
+			ecc:  []byte{0xC4, 0x23, 0x27, 0x77, 0xEB, 0xD7, 0xE7, 0xE2, 0x5D, 0x17},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rsRemainder(tt.data, rsGenerator(len(tt.ecc))); !bytes.Equal(got, tt.ecc) {
+				t.Errorf("ecc = % X, want % X", got, tt.ecc)
+			}
+		})
+	}
+}
+
+func TestBuildDataCodewords(t *testing.T) {
+	// 0100 | 00000101 | "hello" | терминатор 0000, затем чередование EC/11 до 16 байт
+	want := []byte{0x40, 0x56, 0x86, 0x56, 0xC6, 0xC6, 0xF0, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC}
+	if got := buildDataCodewords(versions[1], 8, []byte("hello")); !bytes.Equal(got, want) {
+		t.Errorf("codewords = % X, want % X", got, want)
+	}
+}
+
+// Строки формата уровня M для масок 0–7 (ISO/IEC 18004, таблица C.1), бит 14 — старший
+func TestFormatBits(t *testing.T) {
+	want := []int{
+		0b101010000010010,
+		0b101000100100101,
+		0b101111001111100,
+		0b101101101001011,
+		0b100010111111001,
+		0b100000011001110,
+		0b100111110010111,
+		0b100101010100000,
+	}
+	for mask, bits := range want {
+		c := &Code{size: 21, modules: newGrid(21), function: newGrid(21)}
+		c.drawFormatBits(mask)
+
+		// Первая копия — вокруг верхнего левого искателя
+		var first int
+		for i := 0; i <= 5; i++ {
+			first |= bit(c.modules[i][8], i)
+		}
+		first |= bit(c.modules[7][8], 6) | bit(c.modules[8][8], 7) | bit(c.modules[8][7], 8)
+		for i := 9; i < 15; i++ {
+			first |= bit(c.modules[8][14-i], i)
+		}
+		// Вторая — у правого верхнего и левого нижнего искателей
+		var second int
+		for i := 0; i < 8; i++ {
+			second |= bit(c.modules[8][c.size-1-i], i)
+		}
+		for i := 8; i < 15; i++ {
+			second |= bit(c.modules[c.size-15+i][8], i)
+		}
+
+		if first != bits || second != bits {
+			t.Errorf("mask %d: format = %015b / %015b, want %015b", mask, first, second, bits)
+		}
+		if !c.modules[c.size-8][8] {
+			t.Errorf("mask %d: dark module is light", mask)
+		}
+	}
+}
+
+// Информация о версии (ISO/IEC 18004, таблица D.1)
+func TestVersionBits(t *testing.T) {
+	want := map[int]int{
+		7:  0x07C94,
+		8:  0x085BC,
+		9:  0x09A99,
+		10: 0x0A4D3,
+	}
+	for version, bits := range want {
+		size := version*4 + 17
+		c := &Code{size: size, modules: newGrid(size), function: newGrid(size)}
+		c.drawVersion(version)
+
+		var upperRight, lowerLeft int
+		for i := 0; i < 18; i++ {
+			a, b := size-11+i%3, i/3
+			upperRight |= bit(c.modules[b][a], i)
+			lowerLeft |= bit(c.modules[a][b], i)
+		}
+		if upperRight != bits || lowerLeft != bits {
+			t.Errorf("version %d: bits = %018b / %018b, want %018b", version, upperRight, lowerLeft, bits)
+		}
+	}
+}
+
+func TestEncodeVersion(t *testing.T) {
+	tests := []struct {
+		length int
+		size   int
+	}{
+		{14, 21},  // версия 1: 16 байт данных, 2 — на режим и длину
+		{15, 25},  // не влезает в версию 1
+		{180, 53}, // версия 9: 182 байта данных
+		{181, 57}, // версия 10, длина — 16 бит
+		{213, 57}, // предел
+	}
+	for _, tt := range tests {
+		code, err := Encode(bytes.Repeat([]byte("a"), tt.length))
+		if err != nil {
+			t.Fatalf("%d bytes: %v", tt.length, err)
+		}
+		if code.size != tt.size {
+			t.Errorf("%d bytes: size = %d, want %d", tt.length, code.size, tt.size)
+		}
+	}
+
+	if _, err := Encode(bytes.Repeat([]byte("a"), 214)); err != ErrTooLong {
+		t.Errorf("214 bytes: err = %v, want ErrTooLong", err)
+	}
+}
+
+func bit(dark bool, i int) int {
+	if dark {
+		return 1 << i
+	}
+	return 0
+}
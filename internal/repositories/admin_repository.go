@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"monoex_backend/internal/models"
 )
@@ -13,19 +14,182 @@ func NewAdminRepository(db *sql.DB) *AdminRepository {
 	return &AdminRepository{db: db}
 }
 
-func (r *AdminRepository) GetAdmin() (*models.Admin, error) {
-	admin := &models.Admin{}
-	err := r.db.QueryRow(`SELECT id, username, password FROM admins LIMIT 1`).Scan(&admin.ID, &admin.Username, &admin.Password)
-	if err == sql.ErrNoRows {
-		return nil, nil
+const adminColumns = `id, username, COALESCE(email, ''), password, role, created_at, updated_at, disabled_at,
+	COALESCE(totp_secret, ''), totp_enabled`
+
+func scanAdmin(row interface{ Scan(...any) error }) (*models.Admin, error) {
+	var a models.Admin
+	if err := row.Scan(&a.ID, &a.Username, &a.Email, &a.Password, &a.Role, &a.CreatedAt, &a.UpdatedAt, &a.DisabledAt,
+		&a.TOTPSecret, &a.TOTPEnabled); err != nil {
+		return nil, err
 	}
+	return &a, nil
+}
+
+func (r *AdminRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM admins`).Scan(&count)
+	return count, err
+}
+
+func (r *AdminRepository) List(ctx context.Context) ([]*models.Admin, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+adminColumns+` FROM admins ORDER BY id`)
 	if err != nil {
 		return nil, err
 	}
-	return admin, nil
+	defer rows.Close()
+
+	var admins []*models.Admin
+	for rows.Next() {
+		admin, err := scanAdmin(rows)
+		if err != nil {
+			return nil, err
+		}
+		admins = append(admins, admin)
+	}
+	return admins, rows.Err()
+}
+
+func (r *AdminRepository) GetByID(ctx context.Context, id int) (*models.Admin, error) {
+	return scanAdmin(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+adminColumns+` FROM admins WHERE id = $1`, id))
+}
+
+func (r *AdminRepository) GetByUsername(ctx context.Context, username string) (*models.Admin, error) {
+	return scanAdmin(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+adminColumns+` FROM admins WHERE username = $1`, username))
+}
+
+// GetByEmail ищет без учёта регистра
+func (r *AdminRepository) GetByEmail(ctx context.Context, email string) (*models.Admin, error) {
+	return scanAdmin(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+adminColumns+` FROM admins WHERE lower(email) = lower($1)`, email))
+}
+
+// Create сохраняет аккаунт; в a.Password должен быть уже хэш
+func (r *AdminRepository) Create(ctx context.Context, a *models.Admin) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO admins (username, email, password, role)
+		VALUES ($1, NULLIF($2, ''), $3, $4)
+		RETURNING id, created_at, updated_at
+	`, a.Username, a.Email, a.Password, a.Role).Scan(&a.ID, &a.CreatedAt, &a.UpdatedAt)
+}
+
+func (r *AdminRepository) UpdateUsername(ctx context.Context, id int, username string) error {
+	return r.exec(ctx, `UPDATE admins SET username = $1, updated_at = now() WHERE id = $2`, username, id)
+}
+
+// UpdateEmail: пустая строка убирает адрес
+func (r *AdminRepository) UpdateEmail(ctx context.Context, id int, email string) error {
+	return r.exec(ctx, `UPDATE admins SET email = NULLIF($1, ''), updated_at = now() WHERE id = $2`, email, id)
+}
+
+func (r *AdminRepository) UpdatePassword(ctx context.Context, id int, hash string) error {
+	return r.exec(ctx, `UPDATE admins SET password = $1, updated_at = now() WHERE id = $2`, hash, id)
+}
+
+// RehashPassword подменяет хэш, только если пароль не сменили с момента проверки
+func (r *AdminRepository) RehashPassword(ctx context.Context, id int, oldHash, newHash string) error {
+	return r.exec(ctx, `UPDATE admins SET password = $1 WHERE id = $2 AND password = $3`, newHash, id, oldHash)
+}
+
+func (r *AdminRepository) UpdateRole(ctx context.Context, id int, role string) error {
+	return r.exec(ctx, `UPDATE admins SET role = $1, updated_at = now() WHERE id = $2`, role, id)
+}
+
+func (r *AdminRepository) SetDisabled(ctx context.Context, id int, disabled bool) error {
+	return r.exec(ctx, `
+		UPDATE admins SET
+			disabled_at = CASE WHEN $1 THEN COALESCE(disabled_at, now()) ELSE NULL END,
+			updated_at = now()
+		WHERE id = $2
+	`, disabled, id)
+}
+
+// SetTOTP сохраняет секрет и признак включения (пустой секрет — выключить)
+func (r *AdminRepository) SetTOTP(ctx context.Context, id int, secret string, enabled bool) error {
+	return r.exec(ctx, `
+		UPDATE admins SET totp_secret = NULLIF($1, ''), totp_enabled = $2, totp_last_step = NULL, updated_at = now()
+		WHERE id = $3
+	`, secret, enabled, id)
+}
+
+// UseTOTPStep запоминает принятый шаг; false — код этого или более раннего шага уже использован
+func (r *AdminRepository) UseTOTPStep(ctx context.Context, id int, step int64) (bool, error) {
+	err := r.exec(ctx, `
+		UPDATE admins SET totp_last_step = $1
+		WHERE id = $2 AND (totp_last_step IS NULL OR totp_last_step < $1)
+	`, step, id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
 }
 
-func (r *AdminRepository) CreateAdmin(username, password string) error {
-	_, err := r.db.Exec(`INSERT INTO admins(username, password) VALUES($1, $2)`, username, password)
+// ReplaceRecoveryCodes удаляет старые резервные коды и сохраняет хэши новых
+func (r *AdminRepository) ReplaceRecoveryCodes(ctx context.Context, id int, hashes []string) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM admin_recovery_codes WHERE admin_id = $1`, id); err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		if _, err := conn(ctx, r.db).ExecContext(ctx, `
+			INSERT INTO admin_recovery_codes (admin_id, code_hash) VALUES ($1, $2)
+		`, id, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode гасит неиспользованный код; false — кода нет или он уже использован
+func (r *AdminRepository) UseRecoveryCode(ctx context.Context, id int, hash string) (bool, error) {
+	err := r.exec(ctx, `
+		UPDATE admin_recovery_codes SET used_at = now()
+		WHERE admin_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`, id, hash)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// LockTable блокирует вставку в admins до конца транзакции (чтение разрешено).
+// Вызывать внутри WithinTx.
+func (r *AdminRepository) LockTable(ctx context.Context) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `LOCK TABLE admins IN EXCLUSIVE MODE`)
 	return err
 }
+
+// Lock блокирует строку админа до конца транзакции. Вызывать внутри WithinTx.
+func (r *AdminRepository) Lock(ctx context.Context, id int) error {
+	var locked int
+	return conn(ctx, r.db).QueryRowContext(ctx, `SELECT id FROM admins WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+}
+
+// LockActiveSuperadmins блокирует строки активных суперадминов до конца
+// транзакции и возвращает их количество. Вызывать внутри WithinTx.
+func (r *AdminRepository) LockActiveSuperadmins(ctx context.Context) (int, error) {
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM (
+			SELECT id FROM admins WHERE role = $1 AND disabled_at IS NULL FOR UPDATE
+		) s
+	`, models.RoleSuperadmin).Scan(&count)
+	return count, err
+}
+
+// exec выполняет UPDATE и возвращает sql.ErrNoRows, если строка не найдена
+func (r *AdminRepository) exec(ctx context.Context, query string, args ...any) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"monoex_backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, created_by, expires_at, last_used_at, revoked_at, created_at`
+
+func scanAPIKey(row interface{ Scan(...any) error }) (*models.APIKey, error) {
+	var k models.APIKey
+	var scopes pq.StringArray
+	if err := row.Scan(&k.ID, &k.Name, &k.Prefix, &k.KeyHash, &scopes, &k.CreatedBy,
+		&k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt); err != nil {
+		return nil, err
+	}
+	for _, s := range scopes {
+		k.Scopes = append(k.Scopes, models.Permission(s))
+	}
+	return &k, nil
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, k *models.APIKey) error {
+	scopes := make(pq.StringArray, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = string(s)
+	}
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, k.Name, k.Prefix, k.KeyHash, scopes, k.CreatedBy, k.ExpiresAt).Scan(&k.ID, &k.CreatedAt)
+}
+
+func (r *APIKeyRepository) GetByID(ctx context.Context, id int) (*models.APIKey, error) {
+	return scanAPIKey(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+apiKeyColumns+` FROM api_keys WHERE id = $1`, id))
+}
+
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	return scanAPIKey(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+apiKeyColumns+` FROM api_keys WHERE prefix = $1`, prefix))
+}
+
+// List возвращает ключи админа (или все, если createdBy == 0)
+func (r *APIKeyRepository) List(ctx context.Context, createdBy int) ([]*models.APIKey, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+apiKeyColumns+` FROM api_keys
+		WHERE $1 = 0 OR created_by = $1
+		ORDER BY created_at DESC
+	`, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL
+	`, id)
+	return err
+}
+
+// TouchLastUsed обновляет время использования не чаще раза в минуту,
+// чтобы не писать в БД на каждый запрос
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE api_keys SET last_used_at = now()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')
+	`, id)
+	return err
+}
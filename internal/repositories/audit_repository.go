@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"monoex_backend/internal/models"
+	"strings"
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Create пишет запись; внутри WithinTx — в той же транзакции, что и само изменение
+func (r *AuditRepository) Create(ctx context.Context, e *models.AuditEntry) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO audit_log (actor_id, actor_name, api_key_id, action, entity_type, entity_id, before, after, ip)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`, e.ActorID, e.ActorName, e.APIKeyID, e.Action, e.EntityType, e.EntityID,
+		nullJSON(e.Before), nullJSON(e.After), e.IP).Scan(&e.ID, &e.CreatedAt)
+}
+
+func (r *AuditRepository) List(ctx context.Context, f models.AuditFilter, limit, offset int) ([]*models.AuditEntry, error) {
+	where, args := auditWhere(f)
+	args = append(args, limit, offset)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, actor_id, actor_name, api_key_id, action, entity_type, entity_id, before, after, ip, created_at
+		FROM audit_log
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*models.AuditEntry
+	for rows.Next() {
+		var e models.AuditEntry
+		var before, after []byte
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.ActorName, &e.APIKeyID, &e.Action, &e.EntityType,
+			&e.EntityID, &before, &after, &e.IP, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Before, e.After = before, after
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *AuditRepository) Count(ctx context.Context, f models.AuditFilter) (int, error) {
+	where, args := auditWhere(f)
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_log `+where, args...).Scan(&count)
+	return count, err
+}
+
+func auditWhere(f models.AuditFilter) (string, []any) {
+	var conds []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.ActorID != 0 {
+		add("actor_id = $%d", f.ActorID)
+	}
+	if f.Action != "" {
+		add("action = $%d", f.Action)
+	}
+	if f.EntityType != "" {
+		add("entity_type = $%d", f.EntityType)
+	}
+	if f.EntityID != 0 {
+		add("entity_id = $%d", f.EntityID)
+	}
+	if f.From != nil {
+		add("created_at >= $%d", *f.From)
+	}
+	if f.To != nil {
+		add("created_at < $%d", *f.To)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// nullJSON превращает пустой снимок в SQL NULL
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"monoex_backend/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type AuthorRepository struct {
+	db *sql.DB
+}
+
+func NewAuthorRepository(db *sql.DB) *AuthorRepository {
+	return &AuthorRepository{db: db}
+}
+
+const authorColumns = `id, slug, name, position, photo_path, bio, admin_id, created_at, updated_at`
+
+func scanAuthor(row interface{ Scan(...any) error }) (*models.Author, error) {
+	var a models.Author
+	if err := row.Scan(&a.ID, &a.Slug, &a.Name, &a.Position, &a.PhotoPath, &a.Bio, &a.AdminID,
+		&a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *AuthorRepository) Create(ctx context.Context, a *models.Author) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO authors (slug, name, position, photo_path, bio, admin_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`, a.Slug, a.Name, a.Position, a.PhotoPath, a.Bio, a.AdminID).Scan(&a.ID, &a.CreatedAt, &a.UpdatedAt)
+}
+
+func (r *AuthorRepository) GetByID(ctx context.Context, id int) (*models.Author, error) {
+	return scanAuthor(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+authorColumns+` FROM authors WHERE id = $1`, id))
+}
+
+func (r *AuthorRepository) GetBySlug(ctx context.Context, slug string) (*models.Author, error) {
+	return scanAuthor(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+authorColumns+` FROM authors WHERE slug = $1`, slug))
+}
+
+// GetByAdmin возвращает автора, связанного с аккаунтом админа
+func (r *AuthorRepository) GetByAdmin(ctx context.Context, adminID int) (*models.Author, error) {
+	return scanAuthor(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+authorColumns+` FROM authors WHERE admin_id = $1`, adminID))
+}
+
+// GetBySlugs возвращает найденных авторов; отсутствующие slug'и просто пропускаются
+func (r *AuthorRepository) GetBySlugs(ctx context.Context, slugs []string) ([]*models.Author, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+authorColumns+` FROM authors WHERE slug = ANY($1)`, pq.Array(slugs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var authors []*models.Author
+	for rows.Next() {
+		a, err := scanAuthor(rows)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, a)
+	}
+	return authors, rows.Err()
+}
+
+// List возвращает всех авторов с числом новостей, опубликованных на момент now
+func (r *AuthorRepository) List(ctx context.Context, now time.Time) ([]*models.Author, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT a.id, a.slug, a.name, a.position, a.photo_path, a.bio, a.admin_id, a.created_at, a.updated_at,
+			COUNT(news.id)
+		FROM authors a
+		LEFT JOIN news_authors na ON na.author_id = a.id
+		LEFT JOIN news ON news.id = na.news_id AND `+publishedWindow(1)+`
+		GROUP BY a.id
+		ORDER BY a.name
+	`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var authors []*models.Author
+	for rows.Next() {
+		var a models.Author
+		if err := rows.Scan(&a.ID, &a.Slug, &a.Name, &a.Position, &a.PhotoPath, &a.Bio, &a.AdminID,
+			&a.CreatedAt, &a.UpdatedAt, &a.PublishedCount); err != nil {
+			return nil, err
+		}
+		authors = append(authors, &a)
+	}
+	return authors, rows.Err()
+}
+
+func (r *AuthorRepository) Update(ctx context.Context, a *models.Author) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE authors SET slug = $1, name = $2, position = $3, photo_path = $4, bio = $5, admin_id = $6,
+			updated_at = now()
+		WHERE id = $7
+	`, a.Slug, a.Name, a.Position, a.PhotoPath, a.Bio, a.AdminID, a.ID)
+	return err
+}
+
+// Delete удаляет автора; его переводы и подписи в новостях удаляются каскадом
+func (r *AuthorRepository) Delete(ctx context.Context, id int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM authors WHERE id = $1`, id)
+	return err
+}
+
+const authorTranslationColumns = `author_id, language, name, position, bio, created_at, updated_at`
+
+// SaveTranslation создаёт перевод или заменяет существующий на том же языке
+func (r *AuthorRepository) SaveTranslation(ctx context.Context, t *models.AuthorTranslation) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO author_translations (author_id, language, name, position, bio)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (author_id, language) DO UPDATE SET
+			name = EXCLUDED.name, position = EXCLUDED.position, bio = EXCLUDED.bio, updated_at = now()
+		RETURNING created_at, updated_at
+	`, t.AuthorID, t.Language, t.Name, t.Position, t.Bio).Scan(&t.CreatedAt, &t.UpdatedAt)
+}
+
+// DeleteTranslation сообщает, был ли перевод
+func (r *AuthorRepository) DeleteTranslation(ctx context.Context, authorID int, language string) (bool, error) {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM author_translations WHERE author_id = $1 AND language = $2`, authorID, language)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// ListTranslations возвращает переводы авторов
+func (r *AuthorRepository) ListTranslations(ctx context.Context, authorIDs []int) ([]*models.AuthorTranslation, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+authorTranslationColumns+` FROM author_translations
+		WHERE author_id = ANY($1)
+		ORDER BY author_id, language
+	`, pq.Array(int64s(authorIDs)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var translations []*models.AuthorTranslation
+	for rows.Next() {
+		var t models.AuthorTranslation
+		if err := rows.Scan(&t.AuthorID, &t.Language, &t.Name, &t.Position, &t.Bio, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		translations = append(translations, &t)
+	}
+	return translations, rows.Err()
+}
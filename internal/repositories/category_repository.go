@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"monoex_backend/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type CategoryRepository struct {
+	db *sql.DB
+}
+
+func NewCategoryRepository(db *sql.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+const categoryColumns = `id, slug, name, description, created_at, updated_at`
+
+func scanCategory(row interface{ Scan(...any) error }) (*models.Category, error) {
+	var c models.Category
+	if err := row.Scan(&c.ID, &c.Slug, &c.Name, &c.Description, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, c *models.Category) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO categories (slug, name, description)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`, c.Slug, c.Name, c.Description).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+}
+
+func (r *CategoryRepository) GetByID(ctx context.Context, id int) (*models.Category, error) {
+	return scanCategory(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+categoryColumns+` FROM categories WHERE id = $1`, id))
+}
+
+// GetBySlugs возвращает найденные рубрики; отсутствующие slug'и просто пропускаются
+func (r *CategoryRepository) GetBySlugs(ctx context.Context, slugs []string) ([]*models.Category, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+categoryColumns+` FROM categories WHERE slug = ANY($1)`, pq.Array(slugs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*models.Category
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
+
+// List возвращает все рубрики с числом новостей, опубликованных на момент now
+func (r *CategoryRepository) List(ctx context.Context, now time.Time) ([]*models.Category, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT c.id, c.slug, c.name, c.description, c.created_at, c.updated_at, COUNT(news.id)
+		FROM categories c
+		LEFT JOIN news_categories nc ON nc.category_id = c.id
+		LEFT JOIN news ON news.id = nc.news_id AND `+publishedWindow(1)+`
+		GROUP BY c.id
+		ORDER BY c.name
+	`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*models.Category
+	for rows.Next() {
+		var c models.Category
+		if err := rows.Scan(&c.ID, &c.Slug, &c.Name, &c.Description, &c.CreatedAt, &c.UpdatedAt, &c.PublishedCount); err != nil {
+			return nil, err
+		}
+		categories = append(categories, &c)
+	}
+	return categories, rows.Err()
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, c *models.Category) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE categories SET slug = $1, name = $2, description = $3, updated_at = now()
+		WHERE id = $4
+	`, c.Slug, c.Name, c.Description, c.ID)
+	return err
+}
+
+// Delete удаляет рубрику; связи с новостями удаляются каскадом
+func (r *CategoryRepository) Delete(ctx context.Context, id int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"monoex_backend/internal/models"
+	"time"
 )
 
 type LegislationRepository struct {
@@ -14,20 +15,26 @@ func NewLegislationRepository(db *sql.DB) *LegislationRepository {
 	return &LegislationRepository{db: db}
 }
 
+const legislationColumns = `id, title, description, file_path, created_at, updated_at,
+	meta_title, meta_description, canonical_url, og_image`
+
 func (r *LegislationRepository) Create(ctx context.Context, l *models.Legislation) error {
-	return r.db.QueryRowContext(ctx, `
-        INSERT INTO legislations (title, description, file_path)
-        VALUES ($1, $2, $3)
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+        INSERT INTO legislations (title, description, file_path,
+            meta_title, meta_description, canonical_url, og_image)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
         RETURNING id, created_at, updated_at
-    `, l.Title, l.Description, l.FilePath).Scan(&l.ID, &l.CreatedAt, &l.UpdatedAt)
+    `, l.Title, l.Description, l.FilePath,
+		l.MetaTitle, l.MetaDescription, l.CanonicalURL, l.OGImage).Scan(&l.ID, &l.CreatedAt, &l.UpdatedAt)
 }
 
 func (r *LegislationRepository) GetByID(ctx context.Context, id int) (*models.Legislation, error) {
 	var l models.Legislation
-	err := r.db.QueryRowContext(ctx, `
-        SELECT id, title, description, file_path, created_at, updated_at
-        FROM legislations WHERE id = $1
-    `, id).Scan(&l.ID, &l.Title, &l.Description, &l.FilePath, &l.CreatedAt, &l.UpdatedAt)
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+        SELECT `+legislationColumns+`
+        FROM legislations WHERE id = $1 AND deleted_at IS NULL
+    `, id).Scan(&l.ID, &l.Title, &l.Description, &l.FilePath, &l.CreatedAt, &l.UpdatedAt,
+		&l.MetaTitle, &l.MetaDescription, &l.CanonicalURL, &l.OGImage)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -39,9 +46,10 @@ func (r *LegislationRepository) GetByID(ctx context.Context, id int) (*models.Le
 }
 
 func (r *LegislationRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Legislation, error) {
-	rows, err := r.db.QueryContext(ctx, `
-        SELECT id, title, description, file_path, created_at, updated_at
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+        SELECT `+legislationColumns+`
         FROM legislations
+        WHERE deleted_at IS NULL
         ORDER BY created_at DESC
         LIMIT $1 OFFSET $2
     `, limit, offset)
@@ -53,7 +61,8 @@ func (r *LegislationRepository) GetAll(ctx context.Context, limit, offset int) (
 	var legislations []*models.Legislation
 	for rows.Next() {
 		var l models.Legislation
-		if err := rows.Scan(&l.ID, &l.Title, &l.Description, &l.FilePath, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		if err := rows.Scan(&l.ID, &l.Title, &l.Description, &l.FilePath, &l.CreatedAt, &l.UpdatedAt,
+			&l.MetaTitle, &l.MetaDescription, &l.CanonicalURL, &l.OGImage); err != nil {
 			return nil, err
 		}
 		legislations = append(legislations, &l)
@@ -62,21 +71,26 @@ func (r *LegislationRepository) GetAll(ctx context.Context, limit, offset int) (
 }
 
 func (r *LegislationRepository) Update(ctx context.Context, l *models.Legislation) error {
-	return r.db.QueryRowContext(ctx, `
+	return conn(ctx, r.db).QueryRowContext(ctx, `
         UPDATE legislations SET
-            title = $1, description = $2, file_path = $3, updated_at = now()
-        WHERE id = $4
+            title = $1, description = $2, file_path = $3,
+            meta_title = $4, meta_description = $5, canonical_url = $6, og_image = $7, updated_at = now()
+        WHERE id = $8
         RETURNING updated_at
-    `, l.Title, l.Description, l.FilePath, l.ID).Scan(&l.UpdatedAt)
+    `, l.Title, l.Description, l.FilePath,
+		l.MetaTitle, l.MetaDescription, l.CanonicalURL, l.OGImage, l.ID).Scan(&l.UpdatedAt)
 }
 
-func (r *LegislationRepository) Delete(ctx context.Context, id int) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM legislations WHERE id = $1`, id)
+// SoftDelete переносит закон в корзину
+func (r *LegislationRepository) SoftDelete(ctx context.Context, id int, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+        UPDATE legislations SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL
+    `, id, at)
 	return err
 }
 
 func (r *LegislationRepository) GetTotalCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM legislations`).Scan(&count)
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM legislations WHERE deleted_at IS NULL`).Scan(&count)
 	return count, err
 }
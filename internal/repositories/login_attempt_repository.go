@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"monoex_backend/internal/models"
+	"time"
+)
+
+type LoginAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewLoginAttemptRepository(db *sql.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+// BlockedUntil возвращает самый поздний срок блокировки среди ключей
+// (nil, если ни один не заблокирован на момент now)
+func (r *LoginAttemptRepository) BlockedUntil(ctx context.Context, now time.Time, keys map[string]string) (*time.Time, error) {
+	var until *time.Time
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT MAX(blocked_until) FROM login_attempts
+		WHERE blocked_until > $1
+		  AND ((scope = 'username' AND key = $2) OR (scope = 'ip' AND key = $3))
+	`, now, keys[models.LoginScopeUsername], keys[models.LoginScopeIP]).Scan(&until)
+	return until, err
+}
+
+// RegisterFailure увеличивает счётчик и возвращает новое число ошибок.
+// Если последняя ошибка была раньше resetBefore, счётчик начинается заново.
+func (r *LoginAttemptRepository) RegisterFailure(ctx context.Context, scope, key string, now, resetBefore time.Time) (int, error) {
+	var failures int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO login_attempts (scope, key, failures, last_failure_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (scope, key) DO UPDATE SET
+			failures = CASE
+				WHEN login_attempts.last_failure_at < $4 THEN 1
+				ELSE login_attempts.failures + 1
+			END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING failures
+	`, scope, key, now, resetBefore).Scan(&failures)
+	return failures, err
+}
+
+func (r *LoginAttemptRepository) SetBlockedUntil(ctx context.Context, scope, key string, until time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE login_attempts SET blocked_until = $3 WHERE scope = $1 AND key = $2
+	`, scope, key, until)
+	return err
+}
+
+// List возвращает счётчики с ошибками, недавние и заблокированные — первыми
+func (r *LoginAttemptRepository) List(ctx context.Context, limit, offset int) ([]*models.LoginAttempt, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT scope, key, failures, last_failure_at, blocked_until
+		FROM login_attempts
+		ORDER BY blocked_until DESC NULLS LAST, last_failure_at DESC
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*models.LoginAttempt
+	for rows.Next() {
+		var a models.LoginAttempt
+		if err := rows.Scan(&a.Scope, &a.Key, &a.Failures, &a.LastFailureAt, &a.BlockedUntil); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, &a)
+	}
+	return attempts, rows.Err()
+}
+
+func (r *LoginAttemptRepository) GetTotalCount(ctx context.Context) (int, error) {
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM login_attempts`).Scan(&count)
+	return count, err
+}
+
+// Delete сбрасывает счётчик; возвращает sql.ErrNoRows, если его не было
+func (r *LoginAttemptRepository) Delete(ctx context.Context, scope, key string) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM login_attempts WHERE scope = $1 AND key = $2
+	`, scope, key)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
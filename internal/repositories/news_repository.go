@@ -3,7 +3,12 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"monoex_backend/internal/models"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 type NewsRepository struct {
@@ -14,48 +19,87 @@ func NewNewsRepository(db *sql.DB) *NewsRepository {
 	return &NewsRepository{db: db}
 }
 
-func (r *NewsRepository) Create(ctx context.Context, n *models.News) error {
-	return r.db.QueryRowContext(ctx, `
-		INSERT INTO news (title, description, full_text, image_path, status, link)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, created_at, updated_at
-	`, n.Title, n.Description, n.FullText, n.ImagePath, n.Status, n.Link).Scan(&n.ID, &n.CreatedAt, &n.UpdatedAt)
-}
+const newsColumns = `id, title, description, full_text, image_path, status, link,
+	publish_at, unpublish_at, published_at, created_at, updated_at,
+	meta_title, meta_description, canonical_url, og_image,
+	pinned, featured, featured_until, priority`
 
-func (r *NewsRepository) GetByID(ctx context.Context, id int) (*models.News, error) {
+func scanNews(row interface{ Scan(...any) error }) (*models.News, error) {
 	var n models.News
-
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id, title, description, full_text, image_path, status, link, created_at, updated_at
-		FROM news WHERE id = $1
-	`, id).Scan(&n.ID, &n.Title, &n.Description, &n.FullText, &n.ImagePath, &n.Status, &n.Link, &n.CreatedAt, &n.UpdatedAt)
-
-	if err != nil {
+	if err := row.Scan(&n.ID, &n.Title, &n.Description, &n.FullText, &n.ImagePath, &n.Status, &n.Link,
+		&n.PublishAt, &n.UnpublishAt, &n.PublishedAt, &n.CreatedAt, &n.UpdatedAt,
+		&n.MetaTitle, &n.MetaDescription, &n.CanonicalURL, &n.OGImage,
+		&n.Pinned, &n.Featured, &n.FeaturedUntil, &n.Priority); err != nil {
 		return nil, err
 	}
-
 	return &n, nil
 }
 
-func (r *NewsRepository) GetByLink(ctx context.Context, link string) (*models.News, error) {
-	var n models.News
+// publishedWindow — условие «новость видна на сайте» на момент $param.
+// Учитывает publish_at/unpublish_at, даже если планировщик ещё не сменил статус.
+// По расписанию выходят только новости на ревью, черновик — никогда.
+// Новости из корзины не видны никогда.
+func publishedWindow(param int) string {
+	return fmt.Sprintf(`(status = 'published' OR (status = 'in_review' AND publish_at <= $%[1]d))
+		AND (unpublish_at IS NULL OR unpublish_at > $%[1]d) AND deleted_at IS NULL`, param)
+}
 
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id, title, description, full_text, image_path, status, link, created_at, updated_at
-		FROM news WHERE link = $1 AND status = 'published'
-	`, link).Scan(&n.ID, &n.Title, &n.Description, &n.FullText, &n.ImagePath, &n.Status, &n.Link, &n.CreatedAt, &n.UpdatedAt)
+// publicationDate — дата публикации в SQL, как News.PublicationDate
+const publicationDate = `COALESCE(published_at, publish_at, created_at)`
 
-	if err != nil {
-		return nil, err
+// publishedWhere — publishedWindow($1) плюс фильтры по рубрике, тегу и автору.
+// EXISTS не размножает строки, поэтому COUNT(*) по тому же условию точен.
+func publishedWhere(now time.Time, f models.NewsFilter) (string, []any) {
+	conds := []string{publishedWindow(1)}
+	args := []any{now}
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
 	}
 
-	return &n, nil
+	if f.Category != "" {
+		add(`EXISTS (SELECT 1 FROM news_categories nc JOIN categories c ON c.id = nc.category_id
+			WHERE nc.news_id = news.id AND c.slug = $%d)`, f.Category)
+	}
+	if f.Tag != "" {
+		add(`EXISTS (SELECT 1 FROM news_tags nt JOIN tags t ON t.id = nt.tag_id
+			WHERE nt.news_id = news.id AND t.slug = $%d)`, f.Tag)
+	}
+	if f.Author != "" {
+		add(`EXISTS (SELECT 1 FROM news_authors na JOIN authors a ON a.id = na.author_id
+			WHERE na.news_id = news.id AND a.slug = $%d)`, f.Author)
+	}
+
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// Create добавляет новость; опубликованная сразу получает время публикации at
+func (r *NewsRepository) Create(ctx context.Context, n *models.News, at time.Time) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO news (title, description, full_text, image_path, status, link, publish_at, unpublish_at,
+			meta_title, meta_description, canonical_url, og_image, published_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CASE WHEN $5 = 'published' THEN $13::timestamp END)
+		RETURNING id, published_at, created_at, updated_at
+	`, n.Title, n.Description, n.FullText, n.ImagePath, n.Status, n.Link, n.PublishAt, n.UnpublishAt,
+		n.MetaTitle, n.MetaDescription, n.CanonicalURL, n.OGImage, at).
+		Scan(&n.ID, &n.PublishedAt, &n.CreatedAt, &n.UpdatedAt)
+}
+
+func (r *NewsRepository) GetByID(ctx context.Context, id int) (*models.News, error) {
+	return scanNews(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+newsColumns+` FROM news WHERE id = $1 AND deleted_at IS NULL`, id))
+}
+
+func (r *NewsRepository) GetByLink(ctx context.Context, link string, now time.Time) (*models.News, error) {
+	return scanNews(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+newsColumns+` FROM news WHERE link = $1 AND `+publishedWindow(2), link, now))
 }
 
 func (r *NewsRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.News, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, description, full_text, image_path, status, link, created_at, updated_at
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+newsColumns+`
 		FROM news
+		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
 	`, limit, offset)
@@ -67,25 +111,32 @@ func (r *NewsRepository) GetAll(ctx context.Context, limit, offset int) ([]*mode
 	var news []*models.News
 
 	for rows.Next() {
-		var n models.News
-		err := rows.Scan(&n.ID, &n.Title, &n.Description, &n.FullText, &n.ImagePath, &n.Status, &n.Link, &n.CreatedAt, &n.UpdatedAt)
+		n, err := scanNews(rows)
 		if err != nil {
 			continue
 		}
-		news = append(news, &n)
+		news = append(news, n)
 	}
 
 	return news, nil
 }
 
-func (r *NewsRepository) GetPublished(ctx context.Context, limit, offset int) ([]*models.News, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, description, full_text, image_path, status, link, created_at, updated_at
+func (r *NewsRepository) GetPublished(ctx context.Context, now time.Time, f models.NewsFilter, limit, offset int) ([]*models.News, error) {
+	where, args := publishedWhere(now, f)
+	args = append(args, limit, offset)
+
+	order := publicationDate + " DESC"
+	if f.PinnedFirst {
+		order = "pinned DESC, CASE WHEN pinned THEN priority END, " + publicationDate + " DESC"
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`
+		SELECT `+newsColumns+`
 		FROM news
-		WHERE status = 'published'
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`, limit, offset)
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, where, order, len(args)-1, len(args)), args...)
 	if err != nil {
 		return nil, err
 	}
@@ -94,47 +145,472 @@ func (r *NewsRepository) GetPublished(ctx context.Context, limit, offset int) ([
 	var news []*models.News
 
 	for rows.Next() {
-		var n models.News
-		err := rows.Scan(&n.ID, &n.Title, &n.Description, &n.FullText, &n.ImagePath, &n.Status, &n.Link, &n.CreatedAt, &n.UpdatedAt)
+		n, err := scanNews(rows)
 		if err != nil {
 			continue
 		}
-		news = append(news, &n)
+		news = append(news, n)
 	}
 
 	return news, nil
 }
 
-func (r *NewsRepository) Update(ctx context.Context, n *models.News) error {
-	_, err := r.db.ExecContext(ctx, `
+// Параметры ts_headline: заголовок подсвечиваем целиком, из текста — до двух фрагментов
+const (
+	headlineTitleOptions   = `StartSel=<mark>, StopSel=</mark>, HighlightAll=true`
+	headlineSnippetOptions = `StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … "`
+)
+
+// searchWhere — совпадение с запросом $1 (websearch-синтаксис: "фраза", -исключить, or) плюс фильтры
+func searchWhere(q string, f models.NewsSearchFilter) (string, []any) {
+	conds := []string{`search_vector @@ websearch_to_tsquery('russian', $1)`, `deleted_at IS NULL`}
+	args := []any{q}
+
+	if f.Published != nil {
+		args = append(args, *f.Published)
+		conds = append(conds, publishedWindow(len(args)))
+	}
+	if f.Status != "" {
+		args = append(args, f.Status)
+		conds = append(conds, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// Search ищет новости по заголовку, описанию и тексту, самые релевантные — первыми
+func (r *NewsRepository) Search(ctx context.Context, q string, f models.NewsSearchFilter, limit, offset int) ([]*models.NewsSearchResult, error) {
+	where, args := searchWhere(q, f)
+	args = append(args, limit, offset)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(`
+		SELECT `+newsColumns+`,
+			ts_rank_cd(search_vector, websearch_to_tsquery('russian', $1)) AS rank,
+			ts_headline('russian', title, websearch_to_tsquery('russian', $1), '`+headlineTitleOptions+`'),
+			ts_headline('russian', description || ' ' || full_text, websearch_to_tsquery('russian', $1), '`+headlineSnippetOptions+`')
+		FROM news
+		%s
+		ORDER BY rank DESC, created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*models.NewsSearchResult
+	for rows.Next() {
+		var n models.News
+		res := models.NewsSearchResult{News: &n}
+		if err := rows.Scan(&n.ID, &n.Title, &n.Description, &n.FullText, &n.ImagePath, &n.Status, &n.Link,
+			&n.PublishAt, &n.UnpublishAt, &n.PublishedAt, &n.CreatedAt, &n.UpdatedAt,
+			&n.MetaTitle, &n.MetaDescription, &n.CanonicalURL, &n.OGImage,
+			&n.Pinned, &n.Featured, &n.FeaturedUntil, &n.Priority,
+			&res.Rank, &res.TitleHighlight, &res.Snippet); err != nil {
+			return nil, err
+		}
+		results = append(results, &res)
+	}
+	return results, rows.Err()
+}
+
+func (r *NewsRepository) SearchCount(ctx context.Context, q string, f models.NewsSearchFilter) (int, error) {
+	where, args := searchWhere(q, f)
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM news `+where, args...).Scan(&count)
+	return count, err
+}
+
+// Update заменяет поля новости; при переходе в published запоминает время публикации at
+func (r *NewsRepository) Update(ctx context.Context, n *models.News, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
 		UPDATE news SET
 			title = $1, description = $2, full_text = $3, image_path = $4, 
-			status = $5, link = $6, updated_at = now()
-		WHERE id = $7
-	`, n.Title, n.Description, n.FullText, n.ImagePath, n.Status, n.Link, n.ID)
+			status = $5, link = $6, publish_at = $7, unpublish_at = $8,
+			meta_title = $9, meta_description = $10, canonical_url = $11, og_image = $12, updated_at = now(),
+			published_at = CASE WHEN $5 = 'published' AND status <> 'published' THEN $14::timestamp ELSE published_at END
+		WHERE id = $13
+	`, n.Title, n.Description, n.FullText, n.ImagePath, n.Status, n.Link, n.PublishAt, n.UnpublishAt,
+		n.MetaTitle, n.MetaDescription, n.CanonicalURL, n.OGImage, n.ID, at)
 	return err
 }
 
-func (r *NewsRepository) UpdateStatus(ctx context.Context, id int, status string) error {
-	_, err := r.db.ExecContext(ctx, `
-		UPDATE news SET status = $1, updated_at = now() WHERE id = $2
-	`, status, id)
+// UpdateStatus меняет статус; при переходе в published запоминает время публикации at
+func (r *NewsRepository) UpdateStatus(ctx context.Context, id int, status string, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE news SET status = $1, updated_at = now(),
+			published_at = CASE WHEN $1 = 'published' AND status <> 'published' THEN $3 ELSE published_at END
+		WHERE id = $2
+	`, status, id, at)
 	return err
 }
 
-func (r *NewsRepository) Delete(ctx context.Context, id int) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM news WHERE id = $1`, id)
+// UpdateSchedule задаёт время автопубликации и автоснятия; nil — не планировать
+func (r *NewsRepository) UpdateSchedule(ctx context.Context, id int, publishAt, unpublishAt *time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE news SET publish_at = $1, unpublish_at = $2, updated_at = now() WHERE id = $3
+	`, publishAt, unpublishAt, id)
+	return err
+}
+
+// GetFeatured возвращает видимые на сайте новости из «главного», у которых не истёк featured_until,
+// в ручном порядке priority
+func (r *NewsRepository) GetFeatured(ctx context.Context, now time.Time, limit int) ([]*models.News, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+newsColumns+`
+		FROM news
+		WHERE featured AND (featured_until IS NULL OR featured_until > $1) AND `+publishedWindow(1)+`
+		ORDER BY priority, `+publicationDate+` DESC
+		LIMIT $2
+	`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var news []*models.News
+	for rows.Next() {
+		n, err := scanNews(rows)
+		if err != nil {
+			return nil, err
+		}
+		news = append(news, n)
+	}
+	return news, rows.Err()
+}
+
+// GetPlaced возвращает все закреплённые и избранные новости в ручном порядке и блокирует их
+func (r *NewsRepository) GetPlaced(ctx context.Context) ([]*models.News, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+newsColumns+`
+		FROM news
+		WHERE (pinned OR featured) AND deleted_at IS NULL
+		ORDER BY priority, `+publicationDate+` DESC, id
+		FOR UPDATE
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var news []*models.News
+	for rows.Next() {
+		n, err := scanNews(rows)
+		if err != nil {
+			return nil, err
+		}
+		news = append(news, n)
+	}
+	return news, rows.Err()
+}
+
+// UpdatePlacement закрепляет новость и ставит её в «главное» или убирает оттуда.
+// Новая в закреплённых и избранных встаёт в конец ручного порядка, убранная оттуда теряет место.
+func (r *NewsRepository) UpdatePlacement(ctx context.Context, id int, p models.NewsPlacement) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE news SET pinned = $1, featured = $2, featured_until = $3,
+			priority = CASE
+				WHEN NOT ($1 OR $2) THEN 0
+				WHEN pinned OR featured THEN priority
+				ELSE (SELECT COALESCE(MAX(priority), 0) + 1 FROM news WHERE (pinned OR featured) AND deleted_at IS NULL)
+			END
+		WHERE id = $4
+	`, p.Pinned, p.Featured, p.FeaturedUntil, id)
+	return err
+}
+
+// Reorder задаёт priority по позиции в ids (первая — 1); остальные закреплённые и избранные
+// идут следом, сохраняя взаимный порядок. Возвращает число обновлённых новостей.
+func (r *NewsRepository) Reorder(ctx context.Context, ids []int) (int, error) {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `
+		WITH listed AS (
+			SELECT id, position FROM unnest($1::int[]) WITH ORDINALITY AS o(id, position)
+		), rest AS (
+			SELECT id, cardinality($1::int[]) + ROW_NUMBER() OVER (ORDER BY priority, `+publicationDate+` DESC, id) AS position
+			FROM news
+			WHERE (pinned OR featured) AND deleted_at IS NULL AND id <> ALL($1::int[])
+		)
+		UPDATE news SET priority = o.position
+		FROM (SELECT id, position FROM listed UNION ALL SELECT id, position FROM rest) o
+		WHERE news.id = o.id AND (news.pinned OR news.featured) AND news.deleted_at IS NULL
+	`, pq.Array(int64s(ids)))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// LockDue выбирает новости, у которых наступило время сменить статус (публикуются
+// только новости на ревью), и блокирует их.
+// SKIP LOCKED: несколько экземпляров приложения разбирают разные строки.
+// Вызывать внутри транзакции.
+func (r *NewsRepository) LockDue(ctx context.Context, now time.Time, limit int) ([]*models.News, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+newsColumns+`
+		FROM news
+		WHERE deleted_at IS NULL
+		  AND ((status = 'in_review' AND publish_at <= $1 AND (unpublish_at IS NULL OR unpublish_at > $1))
+		   OR (status = 'published' AND unpublish_at <= $1))
+		ORDER BY id
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var news []*models.News
+	for rows.Next() {
+		n, err := scanNews(rows)
+		if err != nil {
+			return nil, err
+		}
+		news = append(news, n)
+	}
+	return news, rows.Err()
+}
+
+// LinksWithBase возвращает занятые ссылки вида base и base-N, включая прежние ссылки новостей
+// и ссылки переводов (иначе новая ссылка заслонила бы перевод)
+func (r *NewsRepository) LinksWithBase(ctx context.Context, base string) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT link FROM news WHERE link = $1 OR link LIKE $1 || '-%'
+		UNION
+		SELECT link FROM news_link_history WHERE link = $1 OR link LIKE $1 || '-%'
+		UNION
+		SELECT link FROM news_translations WHERE link = $1 OR link LIKE $1 || '-%'
+	`, base)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []string
+	for rows.Next() {
+		var link string
+		if err := rows.Scan(&link); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// RetireLink сохраняет прежнюю ссылку новости в истории
+func (r *NewsRepository) RetireLink(ctx context.Context, newsID int, link string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO news_link_history (link, news_id) VALUES ($1, $2)
+		ON CONFLICT (link) DO NOTHING
+	`, link, newsID)
+	return err
+}
+
+// ReleaseLink убирает ссылку из истории, когда новость возвращает её себе
+func (r *NewsRepository) ReleaseLink(ctx context.Context, newsID int, link string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM news_link_history WHERE link = $1 AND news_id = $2
+	`, link, newsID)
+	return err
+}
+
+// GetByRetiredLink находит опубликованную новость по её прежней ссылке
+func (r *NewsRepository) GetByRetiredLink(ctx context.Context, link string, now time.Time) (*models.News, error) {
+	return scanNews(conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+newsColumns+` FROM news
+		WHERE id = (SELECT news_id FROM news_link_history WHERE link = $1)
+		AND `+publishedWindow(2), link, now))
+}
+
+// sitemapEntries — все публичные страницы новостей: основная ссылка и ссылки
+// опубликованных переводов на языках сайта. Порядок стабилен для разбиения на файлы.
+func sitemapEntries() string {
+	return `
+		SELECT id, '' AS language, link, updated_at FROM news WHERE ` + publishedWindow(1) + `
+		UNION ALL
+		SELECT t.news_id, t.language, t.link, GREATEST(t.updated_at, news.updated_at)
+		FROM news_translations t JOIN news ON news.id = t.news_id
+		WHERE t.published AND t.language = ANY($2) AND ` + publishedWindow(1)
+}
+
+// SitemapPages возвращает lastmod (самое свежее updated_at) каждого файла sitemap по size ссылок
+func (r *NewsRepository) SitemapPages(ctx context.Context, now time.Time, languages []string, size int) ([]time.Time, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT page, MAX(updated_at) FROM (
+			SELECT (ROW_NUMBER() OVER (ORDER BY id, language) - 1) / $3 AS page, updated_at
+			FROM (`+sitemapEntries()+`) e
+		) p
+		GROUP BY page
+		ORDER BY page
+	`, now, pq.Array(languages), size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pages []time.Time
+	for rows.Next() {
+		var page int
+		var lastMod time.Time
+		if err := rows.Scan(&page, &lastMod); err != nil {
+			return nil, err
+		}
+		pages = append(pages, lastMod)
+	}
+	return pages, rows.Err()
+}
+
+// SitemapLinks возвращает страницы для одного файла sitemap
+func (r *NewsRepository) SitemapLinks(ctx context.Context, now time.Time, languages []string, limit, offset int) ([]models.NewsPage, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT link, updated_at FROM (`+sitemapEntries()+`) e
+		ORDER BY id, language
+		LIMIT $3 OFFSET $4
+	`, now, pq.Array(languages), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pages []models.NewsPage
+	for rows.Next() {
+		var p models.NewsPage
+		if err := rows.Scan(&p.Link, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		pages = append(pages, p)
+	}
+	return pages, rows.Err()
+}
+
+// SetCategories заменяет рубрики новости
+func (r *NewsRepository) SetCategories(ctx context.Context, newsID int, categoryIDs []int) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM news_categories WHERE news_id = $1`, newsID); err != nil {
+		return err
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO news_categories (news_id, category_id)
+		SELECT $1, unnest($2::int[])
+	`, newsID, pq.Array(int64s(categoryIDs)))
+	return err
+}
+
+// SetTags заменяет теги новости
+func (r *NewsRepository) SetTags(ctx context.Context, newsID int, tagIDs []int) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM news_tags WHERE news_id = $1`, newsID); err != nil {
+		return err
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO news_tags (news_id, tag_id)
+		SELECT $1, unnest($2::int[])
+	`, newsID, pq.Array(int64s(tagIDs)))
+	return err
+}
+
+// SetAuthors заменяет авторов новости; порядок authorIDs — порядок в подписи
+func (r *NewsRepository) SetAuthors(ctx context.Context, newsID int, authorIDs []int) error {
+	if _, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM news_authors WHERE news_id = $1`, newsID); err != nil {
+		return err
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO news_authors (news_id, author_id, position)
+		SELECT $1, a.id, a.ord FROM unnest($2::int[]) WITH ORDINALITY AS a(id, ord)
+	`, newsID, pq.Array(int64s(authorIDs)))
+	return err
+}
+
+// LoadTaxonomy заполняет Categories, Tags, Authors и Bylines у списка новостей тремя запросами
+func (r *NewsRepository) LoadTaxonomy(ctx context.Context, news []*models.News) error {
+	if len(news) == 0 {
+		return nil
+	}
+	byID := make(map[int]*models.News, len(news))
+	ids := make([]int64, 0, len(news))
+	for _, n := range news {
+		n.Categories, n.Tags = []string{}, []string{}
+		n.Authors, n.Bylines = []string{}, []models.Byline{}
+		byID[n.ID] = n
+		ids = append(ids, int64(n.ID))
+	}
+
+	load := func(query string, add func(n *models.News, slug string)) error {
+		rows, err := conn(ctx, r.db).QueryContext(ctx, query, pq.Array(ids))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id int
+			var slug string
+			if err := rows.Scan(&id, &slug); err != nil {
+				return err
+			}
+			add(byID[id], slug)
+		}
+		return rows.Err()
+	}
+
+	if err := load(`
+		SELECT nc.news_id, c.slug FROM news_categories nc JOIN categories c ON c.id = nc.category_id
+		WHERE nc.news_id = ANY($1) ORDER BY c.slug
+	`, func(n *models.News, slug string) { n.Categories = append(n.Categories, slug) }); err != nil {
+		return err
+	}
+	if err := load(`
+		SELECT nt.news_id, t.slug FROM news_tags nt JOIN tags t ON t.id = nt.tag_id
+		WHERE nt.news_id = ANY($1) ORDER BY t.slug
+	`, func(n *models.News, slug string) { n.Tags = append(n.Tags, slug) }); err != nil {
+		return err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT na.news_id, a.id, a.slug, a.name, a.position, a.photo_path
+		FROM news_authors na JOIN authors a ON a.id = na.author_id
+		WHERE na.news_id = ANY($1) ORDER BY na.news_id, na.position
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var b models.Byline
+		if err := rows.Scan(&id, &b.ID, &b.Slug, &b.Name, &b.Position, &b.PhotoPath); err != nil {
+			return err
+		}
+		n := byID[id]
+		n.Authors = append(n.Authors, b.Slug)
+		n.Bylines = append(n.Bylines, b)
+	}
+	return rows.Err()
+}
+
+func int64s(ids []int) []int64 {
+	out := make([]int64, len(ids))
+	for i, id := range ids {
+		out[i] = int64(id)
+	}
+	return out
+}
+
+// SoftDelete переносит новость в корзину; ссылка остаётся за ней до окончательного удаления
+func (r *NewsRepository) SoftDelete(ctx context.Context, id int, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE news SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL
+	`, id, at)
 	return err
 }
 
 func (r *NewsRepository) GetTotalCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM news`).Scan(&count)
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM news WHERE deleted_at IS NULL`).Scan(&count)
 	return count, err
 }
 
-func (r *NewsRepository) GetPublishedCount(ctx context.Context) (int, error) {
+func (r *NewsRepository) GetPublishedCount(ctx context.Context, now time.Time, f models.NewsFilter) (int, error) {
+	where, args := publishedWhere(now, f)
 	var count int
-	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM news WHERE status = 'published'`).Scan(&count)
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM news `+where, args...).Scan(&count)
 	return count, err
 }
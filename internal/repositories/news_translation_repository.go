@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"monoex_backend/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type NewsTranslationRepository struct {
+	db *sql.DB
+}
+
+func NewNewsTranslationRepository(db *sql.DB) *NewsTranslationRepository {
+	return &NewsTranslationRepository{db: db}
+}
+
+const translationColumns = `news_id, language, title, description, full_text, link, published, created_at, updated_at`
+
+func scanTranslation(row interface{ Scan(...any) error }) (*models.NewsTranslation, error) {
+	var t models.NewsTranslation
+	if err := row.Scan(&t.NewsID, &t.Language, &t.Title, &t.Description, &t.FullText, &t.Link,
+		&t.Published, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func scanTranslations(rows *sql.Rows) ([]*models.NewsTranslation, error) {
+	defer rows.Close()
+
+	var translations []*models.NewsTranslation
+	for rows.Next() {
+		t, err := scanTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, t)
+	}
+	return translations, rows.Err()
+}
+
+// Save создаёт перевод или заменяет существующий на том же языке
+func (r *NewsTranslationRepository) Save(ctx context.Context, t *models.NewsTranslation) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO news_translations (news_id, language, title, description, full_text, link, published)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (news_id, language) DO UPDATE SET
+			title = EXCLUDED.title, description = EXCLUDED.description, full_text = EXCLUDED.full_text,
+			link = EXCLUDED.link, published = EXCLUDED.published, updated_at = now()
+		RETURNING created_at, updated_at
+	`, t.NewsID, t.Language, t.Title, t.Description, t.FullText, t.Link, t.Published).
+		Scan(&t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *NewsTranslationRepository) Get(ctx context.Context, newsID int, language string) (*models.NewsTranslation, error) {
+	return scanTranslation(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+translationColumns+` FROM news_translations WHERE news_id = $1 AND language = $2`, newsID, language))
+}
+
+// ListByNews возвращает все переводы новостей (и опубликованные, и нет)
+func (r *NewsTranslationRepository) ListByNews(ctx context.Context, newsIDs []int) ([]*models.NewsTranslation, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+translationColumns+` FROM news_translations
+		WHERE news_id = ANY($1)
+		ORDER BY news_id, language
+	`, pq.Array(int64s(newsIDs)))
+	if err != nil {
+		return nil, err
+	}
+	return scanTranslations(rows)
+}
+
+// ListPublished возвращает опубликованные переводы новостей
+func (r *NewsTranslationRepository) ListPublished(ctx context.Context, newsIDs []int) ([]*models.NewsTranslation, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+translationColumns+` FROM news_translations
+		WHERE news_id = ANY($1) AND published
+		ORDER BY news_id, language
+	`, pq.Array(int64s(newsIDs)))
+	if err != nil {
+		return nil, err
+	}
+	return scanTranslations(rows)
+}
+
+// GetPublishedByLink находит опубликованный перевод видимой на сайте новости по его ссылке.
+// Если одна ссылка есть в нескольких языках, предпочитается language.
+func (r *NewsTranslationRepository) GetPublishedByLink(ctx context.Context, link, language string, now time.Time) (*models.NewsTranslation, error) {
+	return scanTranslation(conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+translationColumns+` FROM news_translations
+		WHERE link = $1 AND published
+		AND news_id IN (SELECT id FROM news WHERE `+publishedWindow(3)+`)
+		ORDER BY language = $2 DESC, language
+		LIMIT 1
+	`, link, language, now))
+}
+
+// LinksWithBase возвращает занятые ссылки вида base и base-N для языка:
+// ссылки новостей и их история (чтобы перевод их не заслонял) и переводы на этом языке
+func (r *NewsTranslationRepository) LinksWithBase(ctx context.Context, language, base string) ([]string, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT link FROM news WHERE link = $1 OR link LIKE $1 || '-%'
+		UNION
+		SELECT link FROM news_link_history WHERE link = $1 OR link LIKE $1 || '-%'
+		UNION
+		SELECT link FROM news_translations WHERE language = $2 AND (link = $1 OR link LIKE $1 || '-%')
+	`, base, language)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []string
+	for rows.Next() {
+		var link string
+		if err := rows.Scan(&link); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// LinkUsedByNews проверяет, носит ли (или носила) другая новость эту ссылку
+func (r *NewsTranslationRepository) LinkUsedByNews(ctx context.Context, link string, newsID int) (bool, error) {
+	var used bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM news WHERE link = $1 AND id <> $2)
+		    OR EXISTS (SELECT 1 FROM news_link_history WHERE link = $1 AND news_id <> $2)
+	`, link, newsID).Scan(&used)
+	return used, err
+}
+
+func (r *NewsTranslationRepository) Delete(ctx context.Context, newsID int, language string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM news_translations WHERE news_id = $1 AND language = $2`, newsID, language)
+	return err
+}
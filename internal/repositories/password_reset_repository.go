@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type PasswordResetRepository struct {
+	db *sql.DB
+}
+
+func NewPasswordResetRepository(db *sql.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{db: db}
+}
+
+// Create сохраняет токен; created_at задаём сами (UTC), с ним сравнивает CreatedSince
+func (r *PasswordResetRepository) Create(ctx context.Context, tokenHash string, adminID int, now, expiresAt time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO admin_password_resets (token_hash, admin_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`, tokenHash, adminID, now, expiresAt)
+	return err
+}
+
+// Consume помечает токен использованным и возвращает admin_id.
+// sql.ErrNoRows — токена нет, он уже использован или истёк.
+func (r *PasswordResetRepository) Consume(ctx context.Context, tokenHash string, now time.Time) (int, error) {
+	var adminID int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE admin_password_resets SET used_at = $2
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+		RETURNING admin_id
+	`, tokenHash, now).Scan(&adminID)
+	return adminID, err
+}
+
+// InvalidateForAdmin гасит все неиспользованные токены админа
+func (r *PasswordResetRepository) InvalidateForAdmin(ctx context.Context, adminID int, now time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE admin_password_resets SET used_at = $2
+		WHERE admin_id = $1 AND used_at IS NULL
+	`, adminID, now)
+	return err
+}
+
+// CreatedSince сообщает, выдавался ли админу токен после since
+func (r *PasswordResetRepository) CreatedSince(ctx context.Context, adminID int, since time.Time) (bool, error) {
+	var exists bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM admin_password_resets WHERE admin_id = $1 AND created_at > $2)
+	`, adminID, since).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"monoex_backend/internal/models"
+	"time"
+)
+
+type PreviewRepository struct {
+	db *sql.DB
+}
+
+func NewPreviewRepository(db *sql.DB) *PreviewRepository {
+	return &PreviewRepository{db: db}
+}
+
+const previewColumns = `id, news_id, created_by, expires_at, revoked_at, created_at`
+
+func scanPreview(row interface{ Scan(...any) error }) (*models.PreviewToken, error) {
+	var p models.PreviewToken
+	if err := row.Scan(&p.ID, &p.NewsID, &p.CreatedBy, &p.ExpiresAt, &p.RevokedAt, &p.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *PreviewRepository) Create(ctx context.Context, p *models.PreviewToken, now time.Time) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO news_preview_tokens (id, news_id, created_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`, p.ID, p.NewsID, p.CreatedBy, p.ExpiresAt, now).Scan(&p.CreatedAt)
+}
+
+func (r *PreviewRepository) Get(ctx context.Context, id string) (*models.PreviewToken, error) {
+	return scanPreview(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+previewColumns+` FROM news_preview_tokens WHERE id = $1`, id))
+}
+
+// ListByNews возвращает ссылки новости, свежие — первыми
+func (r *PreviewRepository) ListByNews(ctx context.Context, newsID int) ([]*models.PreviewToken, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+previewColumns+` FROM news_preview_tokens
+		WHERE news_id = $1
+		ORDER BY created_at DESC
+	`, newsID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var previews []*models.PreviewToken
+	for rows.Next() {
+		p, err := scanPreview(rows)
+		if err != nil {
+			return nil, err
+		}
+		previews = append(previews, p)
+	}
+	return previews, rows.Err()
+}
+
+func (r *PreviewRepository) Revoke(ctx context.Context, id string, now time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE news_preview_tokens SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL
+	`, id, now)
+	return err
+}
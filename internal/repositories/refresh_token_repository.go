@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"monoex_backend/internal/models"
+	"time"
+)
+
+type RefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepository(db *sql.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, t *models.RefreshToken) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO admin_refresh_tokens (id, admin_id, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING created_at
+	`, t.ID, t.AdminID, t.ExpiresAt).Scan(&t.CreatedAt)
+}
+
+func (r *RefreshTokenRepository) GetByID(ctx context.Context, id string) (*models.RefreshToken, error) {
+	var t models.RefreshToken
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT id, admin_id, expires_at, revoked_at, created_at
+		FROM admin_refresh_tokens WHERE id = $1
+	`, id).Scan(&t.ID, &t.AdminID, &t.ExpiresAt, &t.RevokedAt, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Consume атомарно отзывает действующий токен и возвращает его владельца.
+// Если токен уже отозван или истёк, возвращает sql.ErrNoRows.
+func (r *RefreshTokenRepository) Consume(ctx context.Context, id string, now time.Time) (int, error) {
+	var adminID int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		UPDATE admin_refresh_tokens SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2
+		RETURNING admin_id
+	`, id, now).Scan(&adminID)
+	return adminID, err
+}
+
+func (r *RefreshTokenRepository) Revoke(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE admin_refresh_tokens SET revoked_at = now()
+		WHERE id = $1 AND revoked_at IS NULL
+	`, id)
+	return err
+}
+
+func (r *RefreshTokenRepository) RevokeAllForAdmin(ctx context.Context, adminID int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE admin_refresh_tokens SET revoked_at = now()
+		WHERE admin_id = $1 AND revoked_at IS NULL
+	`, adminID)
+	return err
+}
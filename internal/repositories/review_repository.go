@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"monoex_backend/internal/models"
+	"time"
 )
 
 type ReviewRepository struct {
@@ -15,7 +16,7 @@ func NewReviewRepository(db *sql.DB) *ReviewRepository {
 }
 
 func (r *ReviewRepository) Create(ctx context.Context, review *models.Review) error {
-	return r.db.QueryRowContext(ctx, `
+	return conn(ctx, r.db).QueryRowContext(ctx, `
 		INSERT INTO reviews (company_name, service_type, description, pdf_path, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, now(), now())
 		RETURNING id, created_at, updated_at
@@ -25,10 +26,10 @@ func (r *ReviewRepository) Create(ctx context.Context, review *models.Review) er
 
 func (r *ReviewRepository) GetByID(ctx context.Context, id int) (*models.Review, error) {
 	var review models.Review
-	err := r.db.QueryRowContext(ctx, `
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
 		SELECT id, company_name, service_type, description, pdf_path, created_at, updated_at
 		FROM reviews
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`, id).Scan(
 		&review.ID,
 		&review.CompanyName,
@@ -45,9 +46,10 @@ func (r *ReviewRepository) GetByID(ctx context.Context, id int) (*models.Review,
 }
 
 func (r *ReviewRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Review, error) {
-	rows, err := r.db.QueryContext(ctx, `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
 		SELECT id, company_name, service_type, description, pdf_path, created_at, updated_at
 		FROM reviews
+		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
 	`, limit, offset)
@@ -76,10 +78,10 @@ func (r *ReviewRepository) GetAll(ctx context.Context, limit, offset int) ([]*mo
 }
 
 func (r *ReviewRepository) GetByServiceType(ctx context.Context, serviceType string, limit, offset int) ([]*models.Review, error) {
-	rows, err := r.db.QueryContext(ctx, `
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
 		SELECT id, company_name, service_type, description, pdf_path, created_at, updated_at
 		FROM reviews
-		WHERE service_type = $1
+		WHERE service_type = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`, serviceType, limit, offset)
@@ -108,7 +110,7 @@ func (r *ReviewRepository) GetByServiceType(ctx context.Context, serviceType str
 }
 
 func (r *ReviewRepository) Update(ctx context.Context, review *models.Review) error {
-	_, err := r.db.ExecContext(ctx, `
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
 		UPDATE reviews SET
 			company_name = $1,
 			service_type = $2,
@@ -120,13 +122,16 @@ func (r *ReviewRepository) Update(ctx context.Context, review *models.Review) er
 	return err
 }
 
-func (r *ReviewRepository) Delete(ctx context.Context, id int) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM reviews WHERE id = $1`, id)
+// SoftDelete переносит отзыв в корзину
+func (r *ReviewRepository) SoftDelete(ctx context.Context, id int, at time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE reviews SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL
+	`, id, at)
 	return err
 }
 
 func (r *ReviewRepository) GetTotalCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM reviews`).Scan(&count)
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM reviews WHERE deleted_at IS NULL`).Scan(&count)
 	return count, err
 }
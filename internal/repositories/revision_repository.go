@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"monoex_backend/internal/models"
+)
+
+type RevisionRepository struct {
+	db *sql.DB
+}
+
+func NewRevisionRepository(db *sql.DB) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+// Create добавляет следующую по номеру ревизию. Вызывать в транзакции после
+// UPDATE самой записи: блокировка строки упорядочивает параллельные сохранения.
+func (r *RevisionRepository) Create(ctx context.Context, rev *models.Revision) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO content_revisions (entity_type, entity_id, revision, author_id, author_name, content)
+		SELECT $1, $2, COALESCE(MAX(revision), 0) + 1, $3, $4, $5
+		FROM content_revisions WHERE entity_type = $1 AND entity_id = $2
+		RETURNING id, revision, created_at
+	`, rev.EntityType, rev.EntityID, rev.AuthorID, rev.AuthorName, string(rev.Content)).
+		Scan(&rev.ID, &rev.Revision, &rev.CreatedAt)
+}
+
+// List — ревизии записи без содержимого, новые первыми
+func (r *RevisionRepository) List(ctx context.Context, entityType string, entityID, limit, offset int) ([]*models.Revision, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT id, entity_type, entity_id, revision, author_id, author_name, created_at
+		FROM content_revisions
+		WHERE entity_type = $1 AND entity_id = $2
+		ORDER BY revision DESC
+		LIMIT $3 OFFSET $4
+	`, entityType, entityID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []*models.Revision
+	for rows.Next() {
+		var rev models.Revision
+		if err := rows.Scan(&rev.ID, &rev.EntityType, &rev.EntityID, &rev.Revision,
+			&rev.AuthorID, &rev.AuthorName, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, &rev)
+	}
+	return revisions, rows.Err()
+}
+
+func (r *RevisionRepository) Count(ctx context.Context, entityType string, entityID int) (int, error) {
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM content_revisions WHERE entity_type = $1 AND entity_id = $2
+	`, entityType, entityID).Scan(&count)
+	return count, err
+}
+
+func (r *RevisionRepository) Get(ctx context.Context, entityType string, entityID, revision int) (*models.Revision, error) {
+	var rev models.Revision
+	var content []byte
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT id, entity_type, entity_id, revision, author_id, author_name, content, created_at
+		FROM content_revisions
+		WHERE entity_type = $1 AND entity_id = $2 AND revision = $3
+	`, entityType, entityID, revision).Scan(&rev.ID, &rev.EntityType, &rev.EntityID, &rev.Revision,
+		&rev.AuthorID, &rev.AuthorName, &content, &rev.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	rev.Content = content
+	return &rev, nil
+}
+
+// Latest возвращает номер последней ревизии (0 — ревизий нет)
+func (r *RevisionRepository) Latest(ctx context.Context, entityType string, entityID int) (int, error) {
+	var latest int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(revision), 0) FROM content_revisions WHERE entity_type = $1 AND entity_id = $2
+	`, entityType, entityID).Scan(&latest)
+	return latest, err
+}
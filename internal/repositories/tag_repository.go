@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"monoex_backend/internal/models"
+)
+
+type TagRepository struct {
+	db *sql.DB
+}
+
+func NewTagRepository(db *sql.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// Ensure создаёт тег, если его ещё нет, и заполняет ID и название существующего
+func (r *TagRepository) Ensure(ctx context.Context, t *models.Tag) error {
+	return conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO tags (slug, name) VALUES ($1, $2)
+		ON CONFLICT (slug) DO UPDATE SET slug = EXCLUDED.slug
+		RETURNING id, name
+	`, t.Slug, t.Name).Scan(&t.ID, &t.Name)
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"monoex_backend/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// TrashRepository работает с записями в корзине (deleted_at IS NOT NULL) всех типов контента
+type TrashRepository struct {
+	db *sql.DB
+}
+
+func NewTrashRepository(db *sql.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// trashTables — таблица каждого типа контента, который можно удалить в корзину
+var trashTables = map[string]string{
+	models.EntityNews:        "news",
+	models.EntityLegislation: "legislations",
+	models.EntityReview:      "reviews",
+}
+
+// trashEntries — содержимое корзины всех типов одной выборкой
+const trashEntries = `
+	SELECT 'news' AS entity_type, id, title, image_path AS file_path, deleted_at
+	FROM news WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'legislation', id, title, file_path, deleted_at
+	FROM legislations WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'review', id, company_name, pdf_path, deleted_at
+	FROM reviews WHERE deleted_at IS NOT NULL`
+
+func scanTrashItems(rows *sql.Rows) ([]*models.TrashItem, error) {
+	defer rows.Close()
+
+	var items []*models.TrashItem
+	for rows.Next() {
+		var item models.TrashItem
+		if err := rows.Scan(&item.EntityType, &item.ID, &item.Title, &item.FilePath, &item.DeletedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
+
+// List возвращает корзину по типам entityTypes, недавно удалённое — первым
+func (r *TrashRepository) List(ctx context.Context, entityTypes []string, limit, offset int) ([]*models.TrashItem, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT entity_type, id, title, file_path, deleted_at FROM (`+trashEntries+`) t
+		WHERE entity_type = ANY($1)
+		ORDER BY deleted_at DESC, entity_type, id
+		LIMIT $2 OFFSET $3
+	`, pq.Array(entityTypes), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return scanTrashItems(rows)
+}
+
+func (r *TrashRepository) Count(ctx context.Context, entityTypes []string) (int, error) {
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM (`+trashEntries+`) t WHERE entity_type = ANY($1)
+	`, pq.Array(entityTypes)).Scan(&count)
+	return count, err
+}
+
+// Get возвращает запись из корзины; sql.ErrNoRows — если её там нет
+func (r *TrashRepository) Get(ctx context.Context, entityType string, id int) (*models.TrashItem, error) {
+	var item models.TrashItem
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT entity_type, id, title, file_path, deleted_at FROM (`+trashEntries+`) t
+		WHERE entity_type = $1 AND id = $2
+	`, entityType, id).Scan(&item.EntityType, &item.ID, &item.Title, &item.FilePath, &item.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Expired возвращает записи, удалённые в корзину не позже before, и блокирует их.
+// SKIP LOCKED: несколько экземпляров приложения разбирают разные строки.
+// Вызывать внутри транзакции.
+func (r *TrashRepository) Expired(ctx context.Context, entityType string, before time.Time, limit int) ([]int, error) {
+	table, ok := trashTables[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity type %q", entityType)
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT id FROM `+table+`
+		WHERE deleted_at <= $1
+		ORDER BY deleted_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Restore возвращает запись из корзины
+func (r *TrashRepository) Restore(ctx context.Context, entityType string, id int) error {
+	table, ok := trashTables[entityType]
+	if !ok {
+		return fmt.Errorf("unknown entity type %q", entityType)
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE `+table+` SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL
+	`, id)
+	return err
+}
+
+// Purge окончательно удаляет запись из корзины вместе с её ревизиями.
+// Переводы, рубрики и ссылки предпросмотра новости удаляются каскадом.
+func (r *TrashRepository) Purge(ctx context.Context, entityType string, id int) error {
+	table, ok := trashTables[entityType]
+	if !ok {
+		return fmt.Errorf("unknown entity type %q", entityType)
+	}
+	if _, err := conn(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM content_revisions WHERE entity_type = $1 AND entity_id = $2
+	`, entityType, id); err != nil {
+		return err
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM `+table+` WHERE id = $1 AND deleted_at IS NOT NULL
+	`, id)
+	return err
+}
+
+// FileInUse сообщает, ссылается ли на файл ещё какая-нибудь запись (в том числе в корзине)
+func (r *TrashRepository) FileInUse(ctx context.Context, path string) (bool, error) {
+	var used bool
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM news WHERE image_path = $1)
+		    OR EXISTS (SELECT 1 FROM legislations WHERE file_path = $1)
+		    OR EXISTS (SELECT 1 FROM reviews WHERE pdf_path = $1)
+	`, path).Scan(&used)
+	return used, err
+}
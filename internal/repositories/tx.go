@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+)
+
+// querier — общее у *sql.DB и *sql.Tx
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txContextKey struct{}
+
+// WithinTx выполняет fn в транзакции. Репозитории, вызванные с переданным
+// в fn контекстом, работают внутри этой же транзакции.
+// Вложенный вызов переиспользует уже открытую транзакцию.
+func WithinTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// conn возвращает транзакцию из контекста или сам пул соединений
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
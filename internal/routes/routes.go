@@ -1,69 +1,212 @@
 package routes
 
 import (
+	"context"
 	"database/sql"
 	"github.com/gorilla/mux"
+	"monoex_backend/internal/config"
 	"monoex_backend/internal/handlers"
 	"monoex_backend/internal/middleware"
+	"monoex_backend/internal/models"
 	"monoex_backend/internal/repositories"
 	"monoex_backend/internal/services"
+	"net/http"
 )
 
 // adminService нужен для middleware, чтобы проверять админа
-func RegisterAllRoutes(r *mux.Router, db *sql.DB, adminService *services.AdminService) {
+func RegisterAllRoutes(r *mux.Router, db *sql.DB, cfg *config.Config, adminService *services.AdminService) {
+
+	auditService := services.NewAuditService(db)
+	revisionService := services.NewRevisionService(db)
 
 	legRepo := repositories.NewLegislationRepository(db)
-	legService := services.NewLegislationService(legRepo)
+	legService := services.NewLegislationService(db, legRepo, auditService, revisionService)
 	legHandler := handlers.NewLegislationHandler(legService)
 
 	newsRepo := repositories.NewNewsRepository(db)
-	newsService := services.NewNewsService(newsRepo)
+	newsService := services.NewNewsService(db, newsRepo, auditService, revisionService, cfg.I18n)
 	newsHandler := handlers.NewNewsHandler(newsService)
 
 	reviewRepo := repositories.NewReviewRepository(db)
-	reviewService := services.NewReviewService(reviewRepo)
+	reviewService := services.NewReviewService(db, reviewRepo, auditService)
 	reviewHandler := handlers.NewReviewHandler(reviewService)
 
-	// --- Middleware для админа ---
-	adminMiddleware := middleware.AdminMiddleware(adminService)
+	// --- Middleware для админа: каждому маршруту — своё право ---
+	requireAdmin := func(perm models.Permission) func(func(http.ResponseWriter, *http.Request)) http.HandlerFunc {
+		return middleware.AdminMiddleware(adminService, perm)
+	}
 
 	adminHandler := handlers.NewAdminHandler(adminService)
-	// Регистрация единственного админа
+	// Регистрация первого админа (только с setup-токеном из конфига)
 	r.HandleFunc("/admin/register", adminHandler.Register).Methods("POST")
 
-	// --- CRUD законы (только для админа) ---
-	r.Handle("/legislations", adminMiddleware(legHandler.Create)).Methods("POST")
-	r.Handle("/legislations", adminMiddleware(legHandler.GetAll)).Methods("GET")
-	r.Handle("/legislations/{id}", adminMiddleware(legHandler.GetByID)).Methods("GET")
-	r.Handle("/legislations/{id}", adminMiddleware(legHandler.Update)).Methods("PUT", "PATCH")
-	r.Handle("/legislations/{id}", adminMiddleware(legHandler.Delete)).Methods("DELETE")
-	r.Handle("/files/legislations", adminMiddleware(legHandler.UploadFile)).Methods("POST")
+	// --- Сессии админа (access/refresh токены) ---
+	r.HandleFunc("/admin/login", adminHandler.Login).Methods("POST")
+	r.HandleFunc("/admin/refresh", adminHandler.Refresh).Methods("POST")
+	r.HandleFunc("/admin/logout", adminHandler.Logout).Methods("POST")
+
+	// --- Сброс забытого пароля по email ---
+	r.HandleFunc("/admin/password/forgot", adminHandler.ForgotPassword).Methods("POST")
+	r.HandleFunc("/admin/password/reset", adminHandler.ConfirmPasswordReset).Methods("POST")
+
+	// --- Свой аккаунт (любая роль) ---
+	r.Handle("/admin/me", requireAdmin(models.PermOwnAccount)(adminHandler.Me)).Methods("GET")
+	r.Handle("/admin/me/password", requireAdmin(models.PermOwnAccount)(adminHandler.ChangePassword)).Methods("PUT")
+	r.Handle("/admin/me/email", requireAdmin(models.PermOwnAccount)(adminHandler.ChangeEmail)).Methods("PUT")
+	r.Handle("/admin/me/totp/enroll", requireAdmin(models.PermOwnAccount)(adminHandler.EnrollTOTP)).Methods("POST")
+	r.Handle("/admin/me/totp/confirm", requireAdmin(models.PermOwnAccount)(adminHandler.ConfirmTOTP)).Methods("POST")
+	r.Handle("/admin/me/totp/disable", requireAdmin(models.PermOwnAccount)(adminHandler.DisableTOTP)).Methods("POST")
+
+	// --- Аккаунты админов (только суперадмин) ---
+	r.Handle("/admin/admins", requireAdmin(models.PermAdminsManage)(adminHandler.List)).Methods("GET")
+	r.Handle("/admin/admins", requireAdmin(models.PermAdminsManage)(adminHandler.CreateAccount)).Methods("POST")
+	r.Handle("/admin/admins/{id:[0-9]+}", requireAdmin(models.PermAdminsManage)(adminHandler.Rename)).Methods("PATCH")
+	r.Handle("/admin/admins/{id:[0-9]+}/role", requireAdmin(models.PermAdminsManage)(adminHandler.UpdateRole)).Methods("PUT")
+	r.Handle("/admin/admins/{id:[0-9]+}/password", requireAdmin(models.PermAdminsManage)(adminHandler.ResetPassword)).Methods("PUT")
+	r.Handle("/admin/admins/{id:[0-9]+}/email", requireAdmin(models.PermAdminsManage)(adminHandler.UpdateEmail)).Methods("PUT")
+	r.Handle("/admin/admins/{id:[0-9]+}/disable", requireAdmin(models.PermAdminsManage)(adminHandler.Disable)).Methods("POST")
+	r.Handle("/admin/admins/{id:[0-9]+}/enable", requireAdmin(models.PermAdminsManage)(adminHandler.Enable)).Methods("POST")
+
+	// --- API-ключи для машинных клиентов (любая роль, права ключа ⊆ права роли) ---
+	apiKeyHandler := handlers.NewAPIKeyHandler(adminService.APIKeys())
+	r.Handle("/admin/api-keys", requireAdmin(models.PermOwnAccount)(apiKeyHandler.List)).Methods("GET")
+	r.Handle("/admin/api-keys", requireAdmin(models.PermOwnAccount)(apiKeyHandler.Create)).Methods("POST")
+	r.Handle("/admin/api-keys/{id:[0-9]+}", requireAdmin(models.PermOwnAccount)(apiKeyHandler.Revoke)).Methods("DELETE")
+
+	// --- Блокировки после неудачных входов (только суперадмин) ---
+	r.Handle("/admin/lockouts", requireAdmin(models.PermAdminsManage)(adminHandler.ListLockouts)).Methods("GET")
+	r.Handle("/admin/lockouts", requireAdmin(models.PermAdminsManage)(adminHandler.ClearLockout)).Methods("DELETE")
+
+	// --- Журнал аудита изменений контента (только суперадмин) ---
+	auditHandler := handlers.NewAuditHandler(auditService)
+	r.Handle("/admin/audit", requireAdmin(models.PermAuditRead)(auditHandler.List)).Methods("GET")
+
+	// --- CRUD законы (редактор и выше) ---
+	r.Handle("/legislations", requireAdmin(models.PermLegislationWrite)(legHandler.Create)).Methods("POST")
+	r.Handle("/legislations", requireAdmin(models.PermLegislationRead)(legHandler.GetAll)).Methods("GET")
+	r.Handle("/legislations/{id}", requireAdmin(models.PermLegislationRead)(legHandler.GetByID)).Methods("GET")
+	r.Handle("/legislations/{id}", requireAdmin(models.PermLegislationWrite)(legHandler.Update)).Methods("PUT", "PATCH")
+	r.Handle("/legislations/{id}", requireAdmin(models.PermLegislationWrite)(legHandler.Delete)).Methods("DELETE")
+	r.Handle("/files/legislations", requireAdmin(models.PermFilesUpload)(legHandler.UploadFile)).Methods("POST")
+
+	// --- SEO-метаданные закона: Open Graph, Twitter Card, JSON-LD Legislation ---
+	seoHandler := handlers.NewSEOHandler(newsService, legService, cfg.Site)
+	r.Handle("/legislations/{id:[0-9]+}/seo", requireAdmin(models.PermLegislationRead)(seoHandler.Legislation)).Methods("GET")
+
+	// --- Ревизии законов ---
+	legRevisions := handlers.NewRevisionHandler(revisionService, models.EntityLegislation,
+		func(ctx context.Context, id, revision int) (any, error) {
+			return legService.RestoreRevision(ctx, id, revision)
+		})
+	r.Handle("/legislations/{id:[0-9]+}/revisions", requireAdmin(models.PermLegislationRead)(legRevisions.List)).Methods("GET")
+	r.Handle("/legislations/{id:[0-9]+}/revisions/diff", requireAdmin(models.PermLegislationRead)(legRevisions.Diff)).Methods("GET")
+	r.Handle("/legislations/{id:[0-9]+}/revisions/{revision:[0-9]+}", requireAdmin(models.PermLegislationRead)(legRevisions.Get)).Methods("GET")
+	r.Handle("/legislations/{id:[0-9]+}/revisions/{revision:[0-9]+}/restore", requireAdmin(models.PermLegislationWrite)(legRevisions.Restore)).Methods("POST")
 
 	// --- CRUD новости (только для админа, кроме публичных) ---
-	r.Handle("/news", adminMiddleware(newsHandler.Create)).Methods("POST")
-	r.Handle("/news", adminMiddleware(newsHandler.GetAll)).Methods("GET")
-	r.Handle("/news/{id:[0-9]+}", adminMiddleware(newsHandler.GetByID)).Methods("GET")
-	r.Handle("/news/{id:[0-9]+}", adminMiddleware(newsHandler.Update)).Methods("PUT", "PATCH")
-	r.Handle("/news/{id:[0-9]+}", adminMiddleware(newsHandler.Delete)).Methods("DELETE")
+	r.Handle("/news", requireAdmin(models.PermNewsWrite)(newsHandler.Create)).Methods("POST")
+	r.Handle("/news", requireAdmin(models.PermNewsRead)(newsHandler.GetAll)).Methods("GET")
+	r.Handle("/news/{id:[0-9]+}", requireAdmin(models.PermNewsRead)(newsHandler.GetByID)).Methods("GET")
+	r.Handle("/news/{id:[0-9]+}", requireAdmin(models.PermNewsWrite)(newsHandler.Update)).Methods("PUT", "PATCH")
+	r.Handle("/news/{id:[0-9]+}", requireAdmin(models.PermNewsWrite)(newsHandler.Delete)).Methods("DELETE")
+
+	// --- Ревизии новостей ---
+	newsRevisions := handlers.NewRevisionHandler(revisionService, models.EntityNews,
+		func(ctx context.Context, id, revision int) (any, error) {
+			return newsService.RestoreRevision(ctx, id, revision)
+		})
+	r.Handle("/news/{id:[0-9]+}/revisions", requireAdmin(models.PermNewsRead)(newsRevisions.List)).Methods("GET")
+	r.Handle("/news/{id:[0-9]+}/revisions/diff", requireAdmin(models.PermNewsRead)(newsRevisions.Diff)).Methods("GET")
+	r.Handle("/news/{id:[0-9]+}/revisions/{revision:[0-9]+}", requireAdmin(models.PermNewsRead)(newsRevisions.Get)).Methods("GET")
+	r.Handle("/news/{id:[0-9]+}/revisions/{revision:[0-9]+}/restore", requireAdmin(models.PermNewsWrite)(newsRevisions.Restore)).Methods("POST")
+
+	// --- Переводы новостей (ru/kk/en); публиковать перевод — только publisher, проверяет сервис ---
+	r.Handle("/news/{id:[0-9]+}/translations", requireAdmin(models.PermNewsRead)(newsHandler.ListTranslations)).Methods("GET")
+	r.Handle("/news/{id:[0-9]+}/translations/{lang:[a-z]+}", requireAdmin(models.PermNewsWrite)(newsHandler.SaveTranslation)).Methods("PUT")
+	r.Handle("/news/{id:[0-9]+}/translations/{lang:[a-z]+}", requireAdmin(models.PermNewsWrite)(newsHandler.DeleteTranslation)).Methods("DELETE")
 
-	// --- Получить по ссылке (public) ---
+	// --- Ссылки предпросмотра черновиков: выдать и отозвать — редактор, открыть — по токену (public) ---
+	previewHandler := handlers.NewPreviewHandler(services.NewPreviewService(db, cfg.Auth, newsService, auditService), newsService)
+	r.Handle("/news/{id:[0-9]+}/previews", requireAdmin(models.PermNewsRead)(previewHandler.List)).Methods("GET")
+	r.Handle("/news/{id:[0-9]+}/previews", requireAdmin(models.PermNewsWrite)(previewHandler.Create)).Methods("POST")
+	r.Handle("/news/{id:[0-9]+}/previews/{preview:[0-9a-f]+}", requireAdmin(models.PermNewsWrite)(previewHandler.Revoke)).Methods("DELETE")
+	r.HandleFunc("/news/preview/{token}", previewHandler.Open).Methods("GET")
+
+	// --- Получить по ссылке (public, язык — ?lang= или Accept-Language) ---
 	r.HandleFunc("/news/by-link/{link}", newsHandler.GetByLink).Methods("GET")
 
-	// --- Получить только опубликованные новости (public) ---
+	// --- SEO-метаданные новости по ссылке (public): Open Graph, Twitter Card, JSON-LD NewsArticle ---
+	r.HandleFunc("/seo/news/{link}", seoHandler.News).Methods("GET")
+
+	// --- Получить только опубликованные новости (public): ?category=, ?tag=, ?author=, ?pinned_first=true — закреплённые первыми ---
 	r.HandleFunc("/news/published", newsHandler.GetPublished).Methods("GET")
 
-	// --- Publish / Unpublish (только админ) ---
-	r.Handle("/news/{id:[0-9]+}/publish", adminMiddleware(newsHandler.Publish)).Methods("POST")
-	r.Handle("/news/{id:[0-9]+}/unpublish", adminMiddleware(newsHandler.Unpublish)).Methods("POST")
+	// --- Блок «главное» на главной (public) ---
+	r.HandleFunc("/news/featured", newsHandler.GetFeatured).Methods("GET")
+
+	// --- RSS и Atom (public): ?category=, ?tag=, ?author=, ?lang= ---
+	feedHandler := handlers.NewFeedHandler(newsService, cfg.Site)
+	r.HandleFunc("/news/feed.rss", feedHandler.RSS).Methods("GET")
+	r.HandleFunc("/news/feed.atom", feedHandler.Atom).Methods("GET")
+
+	// --- Sitemap для поисковиков (public) ---
+	sitemapHandler := handlers.NewSitemapHandler(newsService, cfg.Site)
+	r.HandleFunc("/sitemap.xml", sitemapHandler.Index).Methods("GET")
+	r.HandleFunc("/sitemaps/news-{page:[0-9]+}.xml", sitemapHandler.News).Methods("GET")
+
+	// --- Полнотекстовый поиск: опубликованные (public) и все статусы (админ) ---
+	r.HandleFunc("/news/search", newsHandler.Search).Methods("GET")
+	r.Handle("/admin/news/search", requireAdmin(models.PermNewsRead)(newsHandler.SearchAll)).Methods("GET")
+
+	// --- Publish / Unpublish (publisher и суперадмин) ---
+	r.Handle("/news/{id:[0-9]+}/publish", requireAdmin(models.PermNewsPublish)(newsHandler.Publish)).Methods("POST")
+	r.Handle("/news/{id:[0-9]+}/unpublish", requireAdmin(models.PermNewsPublish)(newsHandler.Unpublish)).Methods("POST")
+	r.Handle("/news/{id:[0-9]+}/schedule", requireAdmin(models.PermNewsPublish)(newsHandler.Schedule)).Methods("PUT")
+
+	// --- Статусы: ревью и архив (редактор; снять с публикации — только publisher, проверяет сервис) ---
+	r.Handle("/news/{id:[0-9]+}/submit", requireAdmin(models.PermNewsWrite)(newsHandler.Submit)).Methods("POST")
+	r.Handle("/news/{id:[0-9]+}/archive", requireAdmin(models.PermNewsWrite)(newsHandler.Archive)).Methods("POST")
+	r.Handle("/news/{id:[0-9]+}/status", requireAdmin(models.PermNewsWrite)(newsHandler.UpdateStatus)).Methods("PUT")
+
+	// --- Закрепление, «главное» и ручной порядок (редактор) ---
+	r.Handle("/news/{id:[0-9]+}/placement", requireAdmin(models.PermNewsWrite)(newsHandler.SetPlacement)).Methods("PUT")
+	r.Handle("/news/order", requireAdmin(models.PermNewsWrite)(newsHandler.Reorder)).Methods("PUT")
+
+	// --- Рубрики новостей: список с числом публикаций (public), правка — редактор ---
+	categoryHandler := handlers.NewCategoryHandler(services.NewCategoryService(db, auditService))
+	r.HandleFunc("/categories", categoryHandler.List).Methods("GET")
+	r.Handle("/categories", requireAdmin(models.PermNewsWrite)(categoryHandler.Create)).Methods("POST")
+	r.Handle("/categories/{id:[0-9]+}", requireAdmin(models.PermNewsRead)(categoryHandler.GetByID)).Methods("GET")
+	r.Handle("/categories/{id:[0-9]+}", requireAdmin(models.PermNewsWrite)(categoryHandler.Update)).Methods("PUT", "PATCH")
+	r.Handle("/categories/{id:[0-9]+}", requireAdmin(models.PermNewsWrite)(categoryHandler.Delete)).Methods("DELETE")
+
+	// --- Авторы: список и профиль с опубликованными новостями (public), правка — редактор,
+	// связь с аккаунтом админа — только суперадмин, проверяет сервис ---
+	authorHandler := handlers.NewAuthorHandler(services.NewAuthorService(db, newsService, auditService), newsService)
+	r.HandleFunc("/authors", authorHandler.List).Methods("GET")
+	r.HandleFunc("/authors/by-slug/{slug}", authorHandler.Profile).Methods("GET")
+	r.Handle("/authors", requireAdmin(models.PermNewsWrite)(authorHandler.Create)).Methods("POST")
+	r.Handle("/authors/{id:[0-9]+}", requireAdmin(models.PermNewsRead)(authorHandler.GetByID)).Methods("GET")
+	r.Handle("/authors/{id:[0-9]+}", requireAdmin(models.PermNewsWrite)(authorHandler.Update)).Methods("PUT", "PATCH")
+	r.Handle("/authors/{id:[0-9]+}", requireAdmin(models.PermNewsWrite)(authorHandler.Delete)).Methods("DELETE")
+	r.Handle("/authors/{id:[0-9]+}/translations/{lang:[a-z]+}", requireAdmin(models.PermNewsWrite)(authorHandler.SaveTranslation)).Methods("PUT")
+	r.Handle("/authors/{id:[0-9]+}/translations/{lang:[a-z]+}", requireAdmin(models.PermNewsWrite)(authorHandler.DeleteTranslation)).Methods("DELETE")
+	r.Handle("/files/authors", requireAdmin(models.PermFilesUpload)(authorHandler.UploadPhoto)).Methods("POST")
 
 	// --- Загрузка изображения (только админ) ---
-	r.Handle("/files/news-image", adminMiddleware(newsHandler.UploadImage)).Methods("POST")
+	r.Handle("/files/news-image", requireAdmin(models.PermFilesUpload)(newsHandler.UploadImage)).Methods("POST")
 
 	// --- CRUD отзывы (только админ) ---
-	r.Handle("/reviews", adminMiddleware(reviewHandler.Create)).Methods("POST")
-	r.Handle("/reviews", adminMiddleware(reviewHandler.GetAll)).Methods("GET")
-	r.Handle("/reviews/{id:[0-9]+}", adminMiddleware(reviewHandler.GetByID)).Methods("GET")
-	r.Handle("/reviews/{id:[0-9]+}", adminMiddleware(reviewHandler.Update)).Methods("PUT", "PATCH")
-	r.Handle("/reviews/{id:[0-9]+}", adminMiddleware(reviewHandler.Delete)).Methods("DELETE")
-	r.Handle("/files/reviews", adminMiddleware(reviewHandler.UploadFile)).Methods("POST")
+	r.Handle("/reviews", requireAdmin(models.PermReviewsWrite)(reviewHandler.Create)).Methods("POST")
+	r.Handle("/reviews", requireAdmin(models.PermReviewsRead)(reviewHandler.GetAll)).Methods("GET")
+	r.Handle("/reviews/{id:[0-9]+}", requireAdmin(models.PermReviewsRead)(reviewHandler.GetByID)).Methods("GET")
+	r.Handle("/reviews/{id:[0-9]+}", requireAdmin(models.PermReviewsWrite)(reviewHandler.Update)).Methods("PUT", "PATCH")
+	r.Handle("/reviews/{id:[0-9]+}", requireAdmin(models.PermReviewsWrite)(reviewHandler.Delete)).Methods("DELETE")
+	r.Handle("/files/reviews", requireAdmin(models.PermFilesUpload)(reviewHandler.UploadFile)).Methods("POST")
+
+	// --- Корзина: удалённые новости, законы и отзывы (права на каждый тип проверяет сервис) ---
+	trashHandler := handlers.NewTrashHandler(services.NewTrashService(db, cfg.Trash, auditService))
+	r.Handle("/trash", requireAdmin("")(trashHandler.List)).Methods("GET")
+	r.Handle("/trash/{type:[a-z]+}/{id:[0-9]+}/restore", requireAdmin("")(trashHandler.Restore)).Methods("POST")
+	r.Handle("/trash/{type:[a-z]+}/{id:[0-9]+}", requireAdmin("")(trashHandler.Purge)).Methods("DELETE")
 }
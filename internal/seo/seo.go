@@ -0,0 +1,261 @@
+// Package seo собирает метаданные страницы для превью в мессенджерах и соцсетях
+// и для поисковиков: теги Open Graph и Twitter Card и JSON-LD schema.org.
+package seo
+
+import (
+	"encoding/json"
+	"html"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Типы schema.org, которые мы описываем
+const (
+	TypeNewsArticle = "NewsArticle"
+	TypeLegislation = "Legislation"
+)
+
+// Page — страница, не зависящая от формата. Все адреса абсолютные.
+type Page struct {
+	Type        string // TypeNewsArticle или TypeLegislation
+	Title       string
+	Description string
+	URL         string // канонический адрес
+	Image       string // пусто — без изображения
+	Language    string
+	SiteName    string
+	SiteURL     string
+	Published   time.Time
+	Modified    time.Time
+	Sections    []string          // рубрики
+	Keywords    []string          // теги
+	Document    string            // файл документа (PDF закона)
+	Alternates  map[string]string // язык → адрес версии страницы на этом языке
+}
+
+// Tag — один <meta>: Open Graph и article:* — property, Twitter Card и description — name
+type Tag struct {
+	Property string `json:"property,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Content  string `json:"content"`
+}
+
+// Meta — итоговые значения, теги, JSON-LD и всё вместе в HTML для вставки в <head>
+type Meta struct {
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	CanonicalURL string            `json:"canonical_url"`
+	Image        string            `json:"image,omitempty"`
+	Language     string            `json:"language,omitempty"`
+	Alternates   map[string]string `json:"alternates,omitempty"`
+	Tags         []Tag             `json:"tags"`
+	JSONLD       json.RawMessage   `json:"json_ld"`
+	HTML         string            `json:"html"`
+}
+
+// Build собирает метаданные страницы
+func Build(p Page) (*Meta, error) {
+	ld, err := jsonLD(p)
+	if err != nil {
+		return nil, err
+	}
+	m := &Meta{
+		Title:        p.Title,
+		Description:  p.Description,
+		CanonicalURL: p.URL,
+		Image:        p.Image,
+		Language:     p.Language,
+		Alternates:   p.Alternates,
+		Tags:         tags(p),
+		JSONLD:       ld,
+	}
+	m.HTML = renderHTML(m)
+	return m, nil
+}
+
+func tags(p Page) []Tag {
+	prop := func(property, content string) Tag { return Tag{Property: property, Content: content} }
+	name := func(name, content string) Tag { return Tag{Name: name, Content: content} }
+
+	t := []Tag{
+		name("description", p.Description),
+		prop("og:type", "article"),
+		prop("og:title", p.Title),
+		prop("og:description", p.Description),
+		prop("og:url", p.URL),
+	}
+	if p.SiteName != "" {
+		t = append(t, prop("og:site_name", p.SiteName))
+	}
+	if p.Language != "" {
+		t = append(t, prop("og:locale", Locale(p.Language)))
+	}
+	for _, lang := range sortedKeys(p.Alternates) {
+		if lang != p.Language {
+			t = append(t, prop("og:locale:alternate", Locale(lang)))
+		}
+	}
+	if p.Image != "" {
+		t = append(t, prop("og:image", p.Image), prop("og:image:alt", p.Title))
+	}
+	if !p.Published.IsZero() {
+		t = append(t, prop("article:published_time", p.Published.UTC().Format(time.RFC3339)))
+	}
+	if !p.Modified.IsZero() {
+		t = append(t, prop("article:modified_time", p.Modified.UTC().Format(time.RFC3339)))
+	}
+	for _, s := range p.Sections {
+		t = append(t, prop("article:section", s))
+	}
+	for _, k := range p.Keywords {
+		t = append(t, prop("article:tag", k))
+	}
+
+	// Без изображения большая карточка Twitter пустая — берём обычную
+	card := "summary"
+	if p.Image != "" {
+		card = "summary_large_image"
+	}
+	t = append(t,
+		name("twitter:card", card),
+		name("twitter:title", p.Title),
+		name("twitter:description", p.Description),
+	)
+	if p.Image != "" {
+		t = append(t, name("twitter:image", p.Image))
+	}
+	return t
+}
+
+type ldThing struct {
+	Type string `json:"@type"`
+	ID   string `json:"@id,omitempty"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type ldMedia struct {
+	Type           string `json:"@type"`
+	ContentURL     string `json:"contentUrl"`
+	EncodingFormat string `json:"encodingFormat,omitempty"`
+}
+
+type ldPage struct {
+	Context          string   `json:"@context"`
+	Type             string   `json:"@type"`
+	Headline         string   `json:"headline,omitempty"`
+	Name             string   `json:"name,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	URL              string   `json:"url"`
+	MainEntityOfPage *ldThing `json:"mainEntityOfPage,omitempty"`
+	Image            []string `json:"image,omitempty"`
+	DatePublished    string   `json:"datePublished,omitempty"`
+	DateModified     string   `json:"dateModified,omitempty"`
+	InLanguage       string   `json:"inLanguage,omitempty"`
+	ArticleSection   []string `json:"articleSection,omitempty"`
+	Keywords         string   `json:"keywords,omitempty"`
+	Publisher        *ldThing `json:"publisher,omitempty"`
+	Encoding         *ldMedia `json:"encoding,omitempty"`
+}
+
+func jsonLD(p Page) (json.RawMessage, error) {
+	ld := ldPage{
+		Context:     "https://schema.org",
+		Type:        p.Type,
+		Description: p.Description,
+		URL:         p.URL,
+		InLanguage:  p.Language,
+	}
+	if !p.Published.IsZero() {
+		ld.DatePublished = p.Published.UTC().Format(time.RFC3339)
+	}
+	if !p.Modified.IsZero() {
+		ld.DateModified = p.Modified.UTC().Format(time.RFC3339)
+	}
+	if p.Image != "" {
+		ld.Image = []string{p.Image}
+	}
+	if p.SiteName != "" {
+		ld.Publisher = &ldThing{Type: "Organization", Name: p.SiteName, URL: p.SiteURL}
+	}
+
+	switch p.Type {
+	case TypeNewsArticle:
+		// Google обрезает headline длиннее 110 символов
+		ld.Headline = Truncate(p.Title, 110)
+		ld.MainEntityOfPage = &ldThing{Type: "WebPage", ID: p.URL}
+		ld.ArticleSection = p.Sections
+		ld.Keywords = strings.Join(p.Keywords, ", ")
+	default:
+		ld.Name = p.Title
+		if p.Document != "" {
+			ld.Encoding = &ldMedia{Type: "LegislationObject", ContentURL: p.Document, EncodingFormat: "application/pdf"}
+		}
+	}
+
+	// json.Marshal экранирует <, > и &, поэтому JSON безопасно вставлять в <script>
+	return json.Marshal(ld)
+}
+
+func renderHTML(m *Meta) string {
+	var b strings.Builder
+	attr := html.EscapeString
+
+	b.WriteString(`<title>` + attr(m.Title) + "</title>\n")
+	b.WriteString(`<link rel="canonical" href="` + attr(m.CanonicalURL) + "\">\n")
+	for _, lang := range sortedKeys(m.Alternates) {
+		b.WriteString(`<link rel="alternate" hreflang="` + attr(lang) + `" href="` + attr(m.Alternates[lang]) + "\">\n")
+	}
+	for _, t := range m.Tags {
+		if t.Property != "" {
+			b.WriteString(`<meta property="` + attr(t.Property) + `" content="` + attr(t.Content) + "\">\n")
+		} else {
+			b.WriteString(`<meta name="` + attr(t.Name) + `" content="` + attr(t.Content) + "\">\n")
+		}
+	}
+	b.WriteString(`<script type="application/ld+json">` + string(m.JSONLD) + "</script>\n")
+	return b.String()
+}
+
+// locales — язык сайта → локаль Open Graph (язык_ТЕРРИТОРИЯ)
+var locales = map[string]string{
+	"ru": "ru_RU",
+	"kk": "kk_KZ",
+	"en": "en_US",
+}
+
+// Locale возвращает локаль Open Graph для языка; неизвестный язык — как есть
+func Locale(language string) string {
+	if l, ok := locales[language]; ok {
+		return l
+	}
+	return language
+}
+
+// Truncate схлопывает пробелы и обрезает текст до max символов по границе слова, добавляя «…»
+func Truncate(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	runes := []rune(text)
+	cut := string(runes[:max-1])
+	// Слово, на котором оборвались, выкидываем целиком
+	if runes[max-1] != ' ' {
+		if i := strings.LastIndex(cut, " "); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return cut + "…"
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
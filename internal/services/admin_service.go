@@ -2,54 +2,748 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"log"
+	"monoex_backend/internal/config"
+	"monoex_backend/internal/mailer"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/qrcode"
+	"monoex_backend/internal/repositories"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrAdminNotFound      = errors.New("admin not found")
+	ErrUsernameTaken      = errors.New("username already taken")
+	ErrEmailTaken         = errors.New("email already taken")
+	ErrInvalidEmail       = errors.New("invalid email address")
+	ErrInvalidResetToken  = errors.New("invalid or expired reset token")
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrLastSuperadmin     = errors.New("at least one active superadmin is required")
+	ErrCannotDisableSelf  = errors.New("cannot disable your own account")
+	ErrAdminExists        = errors.New("admin already exists")
+	ErrSetupDisabled      = errors.New("admin registration is disabled")
+	ErrOTPRequired        = errors.New("two-factor code required")
+	ErrInvalidOTP         = errors.New("invalid two-factor code")
+	ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotPending     = errors.New("two-factor enrollment has not been started")
+)
 
-	"golang.org/x/crypto/bcrypt"
+const (
+	recoveryCodeCount  = 10
+	resetEmailInterval = time.Minute
 )
 
 type AdminService struct {
-	DB *sql.DB
+	DB            *sql.DB
+	repo          *repositories.AdminRepository
+	setupToken    string
+	guard         *LoginGuard
+	passwords     *PasswordHasher
+	totpIssuer    string
+	apiKeys       *APIKeyService
+	tokens        *TokenService
+	refreshTokens *repositories.RefreshTokenRepository
+	accessTTL     time.Duration
+	refreshTTL    time.Duration
+	mailer        *mailer.Mailer
+	resets        *repositories.PasswordResetRepository
+	resetTTL      time.Duration
+	resetURL      string
 }
 
-func NewAdminService(db *sql.DB) *AdminService {
-	return &AdminService{DB: db}
+func NewAdminService(db *sql.DB, cfg config.AuthConfig, mail *mailer.Mailer) *AdminService {
+	return &AdminService{
+		DB:            db,
+		repo:          repositories.NewAdminRepository(db),
+		setupToken:    cfg.SetupToken,
+		guard:         NewLoginGuard(db, cfg.Lockout),
+		passwords:     NewPasswordHasher(cfg.Password),
+		totpIssuer:    cfg.TOTPIssuer,
+		apiKeys:       NewAPIKeyService(db),
+		tokens:        NewTokenService(cfg.TokenSecret),
+		refreshTokens: repositories.NewRefreshTokenRepository(db),
+		accessTTL:     time.Duration(cfg.AccessTokenTTL) * time.Minute,
+		refreshTTL:    time.Duration(cfg.RefreshTokenTTL) * time.Hour,
+		mailer:        mail,
+		resets:        repositories.NewPasswordResetRepository(db),
+		resetTTL:      time.Duration(cfg.ResetTokenTTL) * time.Minute,
+		resetURL:      cfg.ResetURL,
+	}
 }
 
 // Проверка, создан ли админ
 func (s *AdminService) IsAdminCreated(ctx context.Context) (bool, error) {
-	var count int
-	err := s.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM admins").Scan(&count)
+	count, err := s.repo.Count(ctx)
 	return count > 0, err
 }
 
-// Создание единственного админа
-func (s *AdminService) CreateAdmin(ctx context.Context, username, password string) error {
-	exists, err := s.IsAdminCreated(ctx)
+// Создание первого админа (суперадмина) при пустой таблице.
+// Таблица блокируется на время проверки и вставки, поэтому из двух
+// параллельных вызовов успешен только один.
+func (s *AdminService) BootstrapAdmin(ctx context.Context, username, email, password string) (*models.Admin, error) {
+	var admin *models.Admin
+	err := repositories.WithinTx(ctx, s.DB, func(ctx context.Context) error {
+		if err := s.repo.LockTable(ctx); err != nil {
+			return err
+		}
+
+		exists, err := s.IsAdminCreated(ctx)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrAdminExists
+		}
+
+		admin, err = s.CreateAdmin(ctx, username, email, password, models.RoleSuperadmin)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+// BootstrapWithSetupToken — HTTP-вариант BootstrapAdmin: работает только
+// при настроенном auth.setup_token и только пока нет ни одного админа
+func (s *AdminService) BootstrapWithSetupToken(ctx context.Context, setupToken, username, email, password string) (*models.Admin, error) {
+	if s.setupToken == "" {
+		return nil, ErrSetupDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(setupToken), []byte(s.setupToken)) != 1 {
+		return nil, ErrInvalidToken
+	}
+	return s.BootstrapAdmin(ctx, username, email, password)
+}
+
+// Создание аккаунта с ролью (вызывает суперадмин); email необязателен
+func (s *AdminService) CreateAdmin(ctx context.Context, username, email, password, role string) (*models.Admin, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("username and password required")
+	}
+	if !models.IsValidRole(role) {
+		return nil, ErrInvalidRole
+	}
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.passwords.Validate(password); err != nil {
+		return nil, err
+	}
+
+	hash, err := s.passwords.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	admin := &models.Admin{Username: username, Email: email, Password: hash, Role: role}
+	err = s.repo.Create(ctx, admin)
+	if repositories.IsUniqueViolation(err) {
+		return nil, uniqueAdminError(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+// Список всех аккаунтов
+func (s *AdminService) ListAdmins(ctx context.Context) ([]*models.Admin, error) {
+	return s.repo.List(ctx)
+}
+
+// Текущий аккаунт по ID
+func (s *AdminService) GetAdmin(ctx context.Context, id int) (*models.Admin, error) {
+	admin, err := s.repo.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, ErrAdminNotFound
+	}
+	return admin, err
+}
+
+// Переименование аккаунта
+func (s *AdminService) Rename(ctx context.Context, id int, username string) error {
+	if username == "" {
+		return errors.New("username is required")
+	}
+	err := s.repo.UpdateUsername(ctx, id, username)
+	if repositories.IsUniqueViolation(err) {
+		return ErrUsernameTaken
+	}
+	return notFoundAsAdmin(err)
+}
+
+// Смена роли. Последнего активного суперадмина понизить нельзя.
+// Новая роль действует сразу: права по access-токену считаются по роли из БД.
+func (s *AdminService) UpdateRole(ctx context.Context, id int, role string) error {
+	if !models.IsValidRole(role) {
+		return ErrInvalidRole
+	}
+
+	return repositories.WithinTx(ctx, s.DB, func(ctx context.Context) error {
+		if err := s.ensureNotLastSuperadmin(ctx, id, role != models.RoleSuperadmin); err != nil {
+			return err
+		}
+		return notFoundAsAdmin(s.repo.UpdateRole(ctx, id, role))
+	})
+}
+
+// Смена собственного пароля: нужен старый пароль, все сессии завершаются
+func (s *AdminService) ChangePassword(ctx context.Context, id int, oldPassword, newPassword string) error {
+	admin, err := s.GetAdmin(ctx, id)
 	if err != nil {
 		return err
 	}
-	if exists {
-		return errors.New("admin already exists")
+	if ok, _ := s.passwords.Verify(oldPassword, admin.Password); !ok {
+		return ErrInvalidCredentials
 	}
+	return s.setPassword(ctx, id, newPassword)
+}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// Сброс пароля другого админа суперадмином
+func (s *AdminService) ResetPassword(ctx context.Context, id int, newPassword string) error {
+	if _, err := s.GetAdmin(ctx, id); err != nil {
+		return err
+	}
+	return s.setPassword(ctx, id, newPassword)
+}
+
+// Смена email (свой аккаунт или суперадмином); пустая строка убирает адрес
+func (s *AdminService) SetEmail(ctx context.Context, id int, email string) error {
+	email, err := normalizeEmail(email)
 	if err != nil {
 		return err
 	}
+	err = s.repo.UpdateEmail(ctx, id, email)
+	if repositories.IsUniqueViolation(err) {
+		return ErrEmailTaken
+	}
+	return notFoundAsAdmin(err)
+}
 
-	_, err = s.DB.ExecContext(ctx,
-		"INSERT INTO admins (username, password) VALUES ($1, $2)",
-		username, string(hash))
-	return err
+// RequestPasswordReset отправляет на email одноразовую ссылку сброса пароля.
+// Неизвестный адрес не отличается от известного — ошибка не возвращается,
+// письмо уходит в фоне, чтобы время ответа тоже ничего не выдавало.
+func (s *AdminService) RequestPasswordReset(ctx context.Context, email string) error {
+	email, err := normalizeEmail(email)
+	if err != nil || email == "" {
+		return ErrInvalidEmail
+	}
+
+	admin, err := s.repo.GetByEmail(ctx, email)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if admin.DisabledAt != nil {
+		return nil
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	send := false
+	err = repositories.WithinTx(ctx, s.DB, func(ctx context.Context) error {
+		// Не чаще одного письма в минуту на аккаунт: строка админа заблокирована,
+		// поэтому параллельные запросы проверяют лимит по очереди
+		if err := s.repo.Lock(ctx, admin.ID); err != nil {
+			return err
+		}
+		recent, err := s.resets.CreatedSince(ctx, admin.ID, now.Add(-resetEmailInterval))
+		if err != nil || recent {
+			return err
+		}
+		// Новая ссылка отменяет все предыдущие
+		if err := s.resets.InvalidateForAdmin(ctx, admin.ID, now); err != nil {
+			return err
+		}
+		send = true
+		return s.resets.Create(ctx, hashResetToken(token), admin.ID, now, now.Add(s.resetTTL))
+	})
+	if err != nil || !send {
+		return err
+	}
+
+	go func() {
+		if err := s.mailer.Send(admin.Email, "Сброс пароля Monoex", s.resetEmailBody(admin.Username, token)); err != nil {
+			log.Printf("⚠️ Failed to send password reset email to admin %d: %v", admin.ID, err)
+		}
+	}()
+	return nil
+}
+
+// ConfirmPasswordReset задаёт новый пароль по токену из письма.
+// Токен одноразовый; все сессии админа завершаются.
+func (s *AdminService) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	// Политику проверяем до погашения токена, чтобы слабый пароль его не сжёг
+	if err := s.passwords.Validate(newPassword); err != nil {
+		return err
+	}
+
+	return repositories.WithinTx(ctx, s.DB, func(ctx context.Context) error {
+		adminID, err := s.resets.Consume(ctx, hashResetToken(token), time.Now().UTC())
+		if err == sql.ErrNoRows {
+			return ErrInvalidResetToken
+		}
+		if err != nil {
+			return err
+		}
+		return s.setPassword(ctx, adminID, newPassword)
+	})
+}
+
+func (s *AdminService) resetEmailBody(username, token string) string {
+	link := token
+	if s.resetURL != "" {
+		link = s.resetURL + "?token=" + url.QueryEscape(token)
+	}
+	return fmt.Sprintf(`Здравствуйте, %s!
+
+Кто-то запросил сброс пароля для вашего аккаунта в админке Monoex.
+Чтобы задать новый пароль, перейдите по ссылке (действует %d мин., один раз):
+
+%s
+
+Если вы не запрашивали сброс, просто проигнорируйте это письмо.
+`, username, int(s.resetTTL.Minutes()), link)
+}
+
+// Отключение/включение аккаунта. Отключение завершает все сессии
+// и сразу закрывает доступ по уже выданным access-токенам.
+func (s *AdminService) SetDisabled(ctx context.Context, actorID, id int, disabled bool) error {
+	if disabled && actorID == id {
+		return ErrCannotDisableSelf
+	}
+
+	return repositories.WithinTx(ctx, s.DB, func(ctx context.Context) error {
+		if disabled {
+			if err := s.ensureNotLastSuperadmin(ctx, id, true); err != nil {
+				return err
+			}
+		}
+		if err := notFoundAsAdmin(s.repo.SetDisabled(ctx, id, disabled)); err != nil {
+			return err
+		}
+		if disabled {
+			return s.refreshTokens.RevokeAllForAdmin(ctx, id)
+		}
+		return nil
+	})
+}
+
+// Проверка логина/пароля админа (Basic Auth и /admin/login).
+// Если у админа включён TOTP, otp должен содержать код из приложения
+// или неиспользованный резервный код.
+// Неудачные попытки считаются по логину и IP клиента из контекста;
+// при превышении лимита возвращается LoginThrottledError.
+func (s *AdminService) ValidateAdmin(ctx context.Context, username, password, otp string) (*models.Admin, error) {
+	ip := ClientIPFromContext(ctx)
+	if err := s.guard.Check(ctx, username, ip); err != nil {
+		return nil, err
+	}
+
+	admin, rehash, err := s.checkPassword(ctx, username, password)
+	if err == nil && admin.TOTPEnabled {
+		err = s.checkSecondFactor(ctx, admin, otp)
+	}
+	if err == ErrInvalidCredentials || err == ErrInvalidOTP {
+		if guardErr := s.guard.Failure(ctx, username, ip); guardErr != nil {
+			return nil, guardErr
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.guard.Success(ctx, username, ip); err != nil {
+		return nil, err
+	}
+	if rehash {
+		s.rehashPassword(ctx, admin, password)
+	}
+	return admin, nil
+}
+
+// checkPassword проверяет пароль; rehash = true, если хэш устарел
+func (s *AdminService) checkPassword(ctx context.Context, username, password string) (*models.Admin, bool, error) {
+	admin, err := s.repo.GetByUsername(ctx, username)
+	if err == sql.ErrNoRows {
+		return nil, false, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	ok, rehash := s.passwords.Verify(password, admin.Password)
+	if !ok {
+		return nil, false, ErrInvalidCredentials
+	}
+	// Отключённый аккаунт отвергаем только после проверки пароля,
+	// чтобы не раскрывать его состояние подбором
+	if admin.DisabledAt != nil {
+		return nil, false, ErrInvalidCredentials
+	}
+	return admin, rehash, nil
+}
+
+// rehashPassword пересчитывает устаревший хэш по текущим параметрам.
+// Ошибка не мешает входу — попробуем снова при следующем.
+func (s *AdminService) rehashPassword(ctx context.Context, admin *models.Admin, password string) {
+	hash, err := s.passwords.Hash(password)
+	if err == nil {
+		err = s.repo.RehashPassword(ctx, admin.ID, admin.Password, hash)
+	}
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("⚠️ Failed to rehash password for admin %d: %v", admin.ID, err)
+		return
+	}
+	if err == nil {
+		admin.Password = hash
+	}
+}
+
+// checkSecondFactor принимает TOTP-код (каждый шаг — один раз) или резервный код
+func (s *AdminService) checkSecondFactor(ctx context.Context, admin *models.Admin, otp string) error {
+	if otp == "" {
+		return ErrOTPRequired
+	}
+
+	if step, ok := matchTOTP(admin.TOTPSecret, otp, time.Now()); ok {
+		fresh, err := s.repo.UseTOTPStep(ctx, admin.ID, step)
+		if err != nil {
+			return err
+		}
+		if fresh {
+			return nil
+		}
+		return ErrInvalidOTP
+	}
+
+	used, err := s.repo.UseRecoveryCode(ctx, admin.ID, hashRecoveryCode(otp))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidOTP
+	}
+	return nil
+}
+
+// EnrollTOTP создаёт новый (ожидающий подтверждения) секрет и QR-код для него
+func (s *AdminService) EnrollTOTP(ctx context.Context, adminID int) (*models.TOTPEnrollment, error) {
+	admin, err := s.GetAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	uri := totpURI(s.totpIssuer, admin.Username, secret)
+
+	png, err := qrcode.PNG(uri, 6)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := notFoundAsAdmin(s.repo.SetTOTP(ctx, adminID, secret, false)); err != nil {
+		return nil, err
+	}
+
+	return &models.TOTPEnrollment{Secret: secret, OTPAuthURI: uri, QRCodePNG: png}, nil
+}
+
+// ConfirmTOTP включает 2FA по первому коду и возвращает резервные коды (показываются один раз)
+func (s *AdminService) ConfirmTOTP(ctx context.Context, adminID int, code string) ([]string, error) {
+	admin, err := s.GetAdmin(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if admin.TOTPSecret == "" {
+		return nil, ErrTOTPNotPending
+	}
+
+	step, ok := matchTOTP(admin.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidOTP
+	}
+
+	codes, hashes, err := newRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = repositories.WithinTx(ctx, s.DB, func(ctx context.Context) error {
+		if err := s.repo.SetTOTP(ctx, adminID, admin.TOTPSecret, true); err != nil {
+			return err
+		}
+		if _, err := s.repo.UseTOTPStep(ctx, adminID, step); err != nil {
+			return err
+		}
+		return s.repo.ReplaceRecoveryCodes(ctx, adminID, hashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP выключает 2FA; нужны пароль и действующий код
+func (s *AdminService) DisableTOTP(ctx context.Context, adminID int, password, otp string) error {
+	admin, err := s.GetAdmin(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if ok, _ := s.passwords.Verify(password, admin.Password); !ok {
+		return ErrInvalidCredentials
+	}
+	if admin.TOTPEnabled {
+		if err := s.checkSecondFactor(ctx, admin, otp); err != nil {
+			return err
+		}
+	}
+
+	return repositories.WithinTx(ctx, s.DB, func(ctx context.Context) error {
+		if err := s.repo.SetTOTP(ctx, adminID, "", false); err != nil {
+			return err
+		}
+		return s.repo.ReplaceRecoveryCodes(ctx, adminID, nil)
+	})
+}
+
+// Счётчики неудачных входов и блокировки
+func (s *AdminService) ListLockouts(ctx context.Context, limit, offset int) ([]*models.LoginAttempt, int, error) {
+	return s.guard.List(ctx, limit, offset)
+}
+
+// Ручное снятие блокировки логина или IP
+func (s *AdminService) ClearLockout(ctx context.Context, scope, key string) error {
+	return s.guard.Clear(ctx, scope, key)
+}
+
+// Login проверяет пароль (и TOTP, если включён) один раз и выдаёт пару access/refresh токенов
+func (s *AdminService) Login(ctx context.Context, username, password, otp string) (*models.AdminTokens, error) {
+	admin, err := s.ValidateAdmin(ctx, username, password, otp)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueTokens(ctx, admin)
+}
+
+// Refresh обменивает refresh-токен на новую пару (старый токен отзывается).
+// Повторное использование уже отозванного токена отзывает все сессии админа.
+func (s *AdminService) Refresh(ctx context.Context, refreshToken string) (*models.AdminTokens, error) {
+	claims, err := s.tokens.Parse(refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	adminID, err := s.refreshTokens.Consume(ctx, claims.ID, time.Now().UTC())
+	if err == sql.ErrNoRows {
+		stored, getErr := s.refreshTokens.GetByID(ctx, claims.ID)
+		if getErr == nil && stored.RevokedAt != nil {
+			if err := s.refreshTokens.RevokeAllForAdmin(ctx, stored.AdminID); err != nil {
+				return nil, err
+			}
+		}
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.repo.GetByID(ctx, adminID)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if admin.DisabledAt != nil {
+		return nil, ErrInvalidToken
+	}
+	return s.issueTokens(ctx, admin)
+}
+
+// Logout отзывает refresh-токен; all=true завершает все сессии админа
+func (s *AdminService) Logout(ctx context.Context, refreshToken string, all bool) error {
+	claims, err := s.tokens.Parse(refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return err
+	}
+	if all {
+		return s.refreshTokens.RevokeAllForAdmin(ctx, claims.Subject)
+	}
+	return s.refreshTokens.Revoke(ctx, claims.ID)
+}
+
+// AuthenticateToken проверяет access-токен и берёт админа из БД: отключённый аккаунт
+// теряет доступ сразу, а права считаются по текущей роли, а не по роли в токене
+func (s *AdminService) AuthenticateToken(ctx context.Context, token string) (*models.Admin, error) {
+	claims, err := s.tokens.Parse(token, TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.repo.GetByID(ctx, claims.Subject)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if admin.DisabledAt != nil {
+		return nil, ErrInvalidToken
+	}
+	return admin, nil
+}
+
+// AuthenticateAPIKey проверяет ключ машинного клиента (заголовок X-API-Key)
+func (s *AdminService) AuthenticateAPIKey(ctx context.Context, key string) (*models.Admin, error) {
+	return s.apiKeys.Authenticate(ctx, key)
+}
+
+// APIKeys — сервис управления API-ключами
+func (s *AdminService) APIKeys() *APIKeyService {
+	return s.apiKeys
+}
+
+func (s *AdminService) issueTokens(ctx context.Context, admin *models.Admin) (*models.AdminTokens, error) {
+	access, _, err := s.tokens.Sign(TokenClaims{
+		Subject:  admin.ID,
+		Username: admin.Username,
+		Role:     admin.Role,
+		Type:     TokenTypeAccess,
+	}, s.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	jti, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	refresh, refreshExpiresAt, err := s.tokens.Sign(TokenClaims{
+		Subject: admin.ID,
+		Type:    TokenTypeRefresh,
+		ID:      jti,
+	}, s.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshTokens.Create(ctx, &models.RefreshToken{
+		ID:        jti,
+		AdminID:   admin.ID,
+		ExpiresAt: refreshExpiresAt.UTC(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &models.AdminTokens{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.accessTTL.Seconds()),
+	}, nil
+}
+
+func (s *AdminService) setPassword(ctx context.Context, id int, password string) error {
+	if password == "" {
+		return errors.New("password is required")
+	}
+	if err := s.passwords.Validate(password); err != nil {
+		return err
+	}
+
+	hash, err := s.passwords.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	return repositories.WithinTx(ctx, s.DB, func(ctx context.Context) error {
+		if err := notFoundAsAdmin(s.repo.UpdatePassword(ctx, id, hash)); err != nil {
+			return err
+		}
+		return s.refreshTokens.RevokeAllForAdmin(ctx, id)
+	})
 }
 
-// Проверка логина/пароля админа для middleware
-func (s *AdminService) ValidateAdmin(username, password string) bool {
-	var hash string
-	err := s.DB.QueryRow("SELECT password FROM admins WHERE username=$1", username).Scan(&hash)
+// ensureNotLastSuperadmin не даёт лишить систему последнего активного суперадмина.
+// removing — true, если операция выводит аккаунт id из числа активных суперадминов.
+func (s *AdminService) ensureNotLastSuperadmin(ctx context.Context, id int, removing bool) error {
+	active, err := s.repo.LockActiveSuperadmins(ctx)
 	if err != nil {
-		return false
+		return err
+	}
+
+	target, err := s.repo.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return ErrAdminNotFound
 	}
-	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	if err != nil {
+		return err
+	}
+
+	isActiveSuperadmin := target.Role == models.RoleSuperadmin && target.DisabledAt == nil
+	if removing && isActiveSuperadmin && active <= 1 {
+		return ErrLastSuperadmin
+	}
+	return nil
+}
+
+// uniqueAdminError различает занятый логин и занятый email
+func uniqueAdminError(err error) error {
+	if repositories.ViolatedConstraint(err) == "idx_admins_email" {
+		return ErrEmailTaken
+	}
+	return ErrUsernameTaken
+}
+
+// normalizeEmail: пустая строка допустима (адреса нет), иначе — один адрес без имени
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return "", nil
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+func notFoundAsAdmin(err error) error {
+	if err == sql.ErrNoRows {
+		return ErrAdminNotFound
+	}
+	return err
 }
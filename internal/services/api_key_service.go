@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrInvalidScope   = errors.New("invalid api key scope")
+)
+
+// Ключ имеет вид mx_<prefix>_<secret>: по prefix ищем запись, secret сверяем по хэшу
+const apiKeyPrefix = "mx_"
+
+type APIKeyService struct {
+	repo   *repositories.APIKeyRepository
+	admins *repositories.AdminRepository
+}
+
+func NewAPIKeyService(db *sql.DB) *APIKeyService {
+	return &APIKeyService{
+		repo:   repositories.NewAPIKeyRepository(db),
+		admins: repositories.NewAdminRepository(db),
+	}
+}
+
+// Create выпускает ключ. Выдать можно только те права, которые есть у самого админа.
+func (s *APIKeyService) Create(ctx context.Context, actor *models.Admin, name string, scopes []models.Permission, expiresAt *time.Time) (*models.APIKeyCreated, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !models.IsAPIKeyScope(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+		if !actor.Can(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrForbidden, scope)
+		}
+	}
+	if expiresAt != nil {
+		if !expiresAt.After(time.Now()) {
+			return nil, errors.New("expires_at must be in the future")
+		}
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	prefix, err := randomToken(4)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomToken(20)
+	if err != nil {
+		return nil, err
+	}
+	raw := apiKeyPrefix + prefix + "_" + secret
+
+	createdBy := actor.ID
+	key := &models.APIKey{
+		Name:      name,
+		Prefix:    prefix,
+		Scopes:    scopes,
+		CreatedBy: &createdBy,
+		ExpiresAt: expiresAt,
+		KeyHash:   hashAPIKey(raw),
+	}
+	if err := s.repo.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	return &models.APIKeyCreated{APIKey: key, Key: raw}, nil
+}
+
+// List: суперадмин видит все ключи, остальные — только свои
+func (s *APIKeyService) List(ctx context.Context, actor *models.Admin) ([]*models.APIKey, error) {
+	if actor.Can(models.PermAdminsManage) {
+		return s.repo.List(ctx, 0)
+	}
+	return s.repo.List(ctx, actor.ID)
+}
+
+// Revoke отзывает свой ключ (суперадмин — любой)
+func (s *APIKeyService) Revoke(ctx context.Context, actor *models.Admin, id int) error {
+	key, err := s.repo.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	owner := key.CreatedBy != nil && *key.CreatedBy == actor.ID
+	if !owner && !actor.Can(models.PermAdminsManage) {
+		return ErrAPIKeyNotFound
+	}
+	return s.repo.Revoke(ctx, id)
+}
+
+// Authenticate проверяет ключ из X-API-Key. Права ключа дополнительно
+// ограничены текущей ролью создателя: понижение роли сужает и его ключи.
+func (s *APIKeyService) Authenticate(ctx context.Context, raw string) (*models.Admin, error) {
+	rest, ok := strings.CutPrefix(raw, apiKeyPrefix)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	prefix, _, ok := strings.Cut(rest, "_")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	key, err := s.repo.GetByPrefix(ctx, prefix)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(raw)), []byte(key.KeyHash)) != 1 {
+		return nil, ErrInvalidToken
+	}
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now().UTC())) {
+		return nil, ErrInvalidToken
+	}
+	if key.CreatedBy == nil {
+		return nil, ErrInvalidToken
+	}
+
+	creator, err := s.admins.GetByID(ctx, *key.CreatedBy)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if creator.DisabledAt != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var scopes []models.Permission
+	for _, scope := range key.Scopes {
+		if models.RoleHasPermission(creator.Role, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if err := s.repo.TouchLastUsed(ctx, key.ID); err != nil {
+		log.Printf("⚠️ Failed to update api key last_used_at: %v", err)
+	}
+
+	return &models.Admin{
+		ID:       creator.ID,
+		Username: creator.Username,
+		Role:     creator.Role,
+		APIKeyID: key.ID,
+		Scopes:   scopes,
+	}, nil
+}
+
+func hashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
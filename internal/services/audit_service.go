@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+)
+
+type AuditService struct {
+	repo *repositories.AuditRepository
+}
+
+func NewAuditService(db *sql.DB) *AuditService {
+	return &AuditService{repo: repositories.NewAuditRepository(db)}
+}
+
+// Record пишет запись журнала от имени админа из контекста.
+// Вызывать внутри repositories.WithinTx — тогда запись попадёт в ту же транзакцию,
+// что и само изменение. entityID = 0 — у сущности нет ID (например, загруженный файл).
+func (s *AuditService) Record(ctx context.Context, action, entityType string, entityID int, before, after any) error {
+	entry := &models.AuditEntry{
+		Action:     action,
+		EntityType: entityType,
+		IP:         ClientIPFromContext(ctx),
+	}
+	if entityID != 0 {
+		entry.EntityID = &entityID
+	}
+	if admin := AdminFromContext(ctx); admin != nil {
+		actorID := admin.ID
+		entry.ActorID = &actorID
+		entry.ActorName = admin.Username
+		if admin.APIKeyID != 0 {
+			keyID := admin.APIKeyID
+			entry.APIKeyID = &keyID
+		}
+	}
+
+	var err error
+	if entry.Before, err = snapshot(before); err != nil {
+		return err
+	}
+	if entry.After, err = snapshot(after); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, entry)
+}
+
+// List — журнал с фильтрами, новые записи первыми
+func (s *AuditService) List(ctx context.Context, f models.AuditFilter, limit, offset int) ([]*models.AuditEntry, int, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	entries, err := s.repo.List(ctx, f, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.repo.Count(ctx, f)
+	return entries, total, err
+}
+
+// snapshot сериализует состояние сущности; nil (нет состояния) остаётся NULL
+func snapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return data, nil
+}
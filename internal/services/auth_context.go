@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"monoex_backend/internal/models"
+)
+
+type adminContextKey struct{}
+
+// WithAdmin сохраняет аутентифицированного админа в контексте запроса
+func WithAdmin(ctx context.Context, admin *models.Admin) context.Context {
+	return context.WithValue(ctx, adminContextKey{}, admin)
+}
+
+// AdminFromContext возвращает админа, которого положил AdminMiddleware
+func AdminFromContext(ctx context.Context) *models.Admin {
+	admin, _ := ctx.Value(adminContextKey{}).(*models.Admin)
+	return admin
+}
+
+// HasPermission проверяет право текущего админа из контекста
+func HasPermission(ctx context.Context, perm models.Permission) bool {
+	admin := AdminFromContext(ctx)
+	return admin != nil && admin.Can(perm)
+}
+
+type clientIPContextKey struct{}
+
+// WithClientIP сохраняет IP клиента (его определяет middleware.RealIP)
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, ip)
+}
+
+// ClientIPFromContext возвращает IP клиента или пустую строку
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPContextKey{}).(string)
+	return ip
+}
@@ -0,0 +1,330 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+	"monoex_backend/internal/slug"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAuthorSlug = errors.New("author slug may contain only lowercase latin letters, digits and single hyphens (max 80 characters)")
+	ErrAuthorTaken       = errors.New("author slug is already used")
+	ErrAdminLinked       = errors.New("admin is already linked to another author")
+	ErrUnknownAdmin      = errors.New("unknown admin")
+)
+
+// AuthorService — авторы публикаций и их переводы. Автор не аккаунт: связать
+// его с аккаунтом админа (admin_id) может только суперадмин.
+type AuthorService struct {
+	db     *sql.DB
+	repo   *repositories.AuthorRepository
+	admins *repositories.AdminRepository
+	news   *NewsService
+	audit  *AuditService
+}
+
+func NewAuthorService(db *sql.DB, news *NewsService, audit *AuditService) *AuthorService {
+	return &AuthorService{
+		db:     db,
+		repo:   repositories.NewAuthorRepository(db),
+		admins: repositories.NewAdminRepository(db),
+		news:   news,
+		audit:  audit,
+	}
+}
+
+// Create создаёт автора; пустой slug строится из имени
+func (s *AuthorService) Create(ctx context.Context, a *models.Author) error {
+	if err := prepareAuthor(a); err != nil {
+		return err
+	}
+	if a.AdminID != nil && !HasPermission(ctx, models.PermAdminsManage) {
+		return ErrForbidden
+	}
+	err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		if err := s.checkAdmin(ctx, a.AdminID); err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, a); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditCreate, models.EntityAuthor, a.ID, nil, a)
+	})
+	return authorError(err)
+}
+
+// List возвращает всех авторов на языке language с числом опубликованных новостей (public)
+func (s *AuthorService) List(ctx context.Context, language string) ([]*models.Author, error) {
+	authors, err := s.repo.List(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	return authors, s.localize(ctx, authors, language)
+}
+
+// GetByID возвращает автора со всеми переводами (для админки)
+func (s *AuthorService) GetByID(ctx context.Context, id int) (*models.Author, error) {
+	author, err := s.repo.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.repo.ListTranslations(ctx, []int{id})
+	if err != nil {
+		return nil, err
+	}
+	author.Translations = make(map[string]*models.AuthorTranslation, len(translations))
+	for _, t := range translations {
+		author.Translations[t.Language] = t
+	}
+	return author, nil
+}
+
+// Profile возвращает публичный профиль автора на языке language
+// и страницу его опубликованных новостей с их общим числом
+func (s *AuthorService) Profile(ctx context.Context, authorSlug, language string, limit, offset int) (*models.Author, []*models.News, int, error) {
+	author, err := s.repo.GetBySlug(ctx, authorSlug)
+	if err == sql.ErrNoRows {
+		return nil, nil, 0, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if err := s.localize(ctx, []*models.Author{author}, language); err != nil {
+		return nil, nil, 0, err
+	}
+
+	filter := models.NewsFilter{Author: author.Slug}
+	news, err := s.news.GetPublished(ctx, filter, language, limit, offset)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	total, err := s.news.GetPublishedCount(ctx, filter)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	author.PublishedCount = total
+	return author, news, total, nil
+}
+
+// Update заменяет поля автора. Без права admins:manage связь с админом
+// не меняется: пустой admin_id сохраняет прежний, другой — запрещён.
+func (s *AuthorService) Update(ctx context.Context, a *models.Author) error {
+	if err := prepareAuthor(a); err != nil {
+		return err
+	}
+	err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, a.ID)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !sameAdmin(a.AdminID, before.AdminID) {
+			if !HasPermission(ctx, models.PermAdminsManage) {
+				if a.AdminID != nil {
+					return ErrForbidden
+				}
+				a.AdminID = before.AdminID
+			} else if err := s.checkAdmin(ctx, a.AdminID); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.Update(ctx, a); err != nil {
+			return err
+		}
+		after, err := s.repo.GetByID(ctx, a.ID)
+		if err != nil {
+			return err
+		}
+		*a = *after
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityAuthor, a.ID, before, after)
+	})
+	return authorError(err)
+}
+
+// Delete удаляет автора; новости остаются, теряя только его подпись
+func (s *AuthorService) Delete(ctx context.Context, id int) error {
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditDelete, models.EntityAuthor, id, before, nil)
+	})
+}
+
+// SaveTranslation создаёт или заменяет перевод автора на язык, отличный от языка по умолчанию
+func (s *AuthorService) SaveTranslation(ctx context.Context, t *models.AuthorTranslation) error {
+	if !s.news.supportsLanguage(t.Language) || t.Language == s.news.DefaultLanguage() {
+		return fmt.Errorf("%w: %s (expected one of %s except %s, which is edited on the author itself)",
+			ErrUnsupportedLanguage, t.Language, strings.Join(s.news.Languages(), ", "), s.news.DefaultLanguage())
+	}
+	t.Name = strings.TrimSpace(t.Name)
+	t.Position = strings.TrimSpace(t.Position)
+	t.Bio = strings.TrimSpace(t.Bio)
+	if t.Name == "" {
+		return errors.New("name is required")
+	}
+
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		if _, err := s.repo.GetByID(ctx, t.AuthorID); err == sql.ErrNoRows {
+			return ErrNotFound
+		} else if err != nil {
+			return err
+		}
+		before, err := s.translation(ctx, t.AuthorID, t.Language)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SaveTranslation(ctx, t); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityAuthor, t.AuthorID, before, t)
+	})
+}
+
+// DeleteTranslation удаляет перевод автора
+func (s *AuthorService) DeleteTranslation(ctx context.Context, authorID int, language string) error {
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.translation(ctx, authorID, language)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return ErrTranslationNotFound
+		}
+		if _, err := s.repo.DeleteTranslation(ctx, authorID, language); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityAuthor, authorID, before, nil)
+	})
+}
+
+// RecordUpload записывает в журнал загрузку фото автора
+func (s *AuthorService) RecordUpload(ctx context.Context, url string) error {
+	return s.audit.Record(ctx, models.AuditUpload, models.EntityFile, 0, nil, map[string]string{
+		"entity_type": models.EntityAuthor,
+		"url":         url,
+	})
+}
+
+func (s *AuthorService) translation(ctx context.Context, authorID int, language string) (*models.AuthorTranslation, error) {
+	translations, err := s.repo.ListTranslations(ctx, []int{authorID})
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range translations {
+		if t.Language == language {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+// localize подставляет перевод на language, а если его нет — на резервный язык,
+// как у новостей. Связь с админом в публичный ответ не попадает.
+func (s *AuthorService) localize(ctx context.Context, authors []*models.Author, language string) error {
+	if len(authors) == 0 {
+		return nil
+	}
+	ids := make([]int, len(authors))
+	for i, a := range authors {
+		ids[i] = a.ID
+	}
+
+	translations, err := s.repo.ListTranslations(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byAuthor := make(map[int]map[string]*models.AuthorTranslation)
+	for _, t := range translations {
+		if byAuthor[t.AuthorID] == nil {
+			byAuthor[t.AuthorID] = make(map[string]*models.AuthorTranslation)
+		}
+		byAuthor[t.AuthorID][t.Language] = t
+	}
+
+	defaultLanguage := s.news.DefaultLanguage()
+	for _, a := range authors {
+		a.AdminID = nil
+		a.Language = defaultLanguage
+		for _, candidate := range []string{language, s.news.FallbackLanguage()} {
+			if candidate == defaultLanguage {
+				break
+			}
+			if t := byAuthor[a.ID][candidate]; t != nil {
+				a.Name, a.Bio = t.Name, t.Bio
+				if t.Position != "" {
+					a.Position = t.Position
+				}
+				a.Language = t.Language
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// checkAdmin проверяет, что админ, с которым связывают автора, существует
+func (s *AuthorService) checkAdmin(ctx context.Context, adminID *int) error {
+	if adminID == nil {
+		return nil
+	}
+	if _, err := s.admins.GetByID(ctx, *adminID); err == sql.ErrNoRows {
+		return ErrUnknownAdmin
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// authorError переводит нарушения уникальности в ошибки сервиса
+func authorError(err error) error {
+	if !repositories.IsUniqueViolation(err) {
+		return err
+	}
+	if repositories.ViolatedConstraint(err) == "authors_admin_id_key" {
+		return ErrAdminLinked
+	}
+	return ErrAuthorTaken
+}
+
+func sameAdmin(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func prepareAuthor(a *models.Author) error {
+	a.Name = strings.TrimSpace(a.Name)
+	a.Position = strings.TrimSpace(a.Position)
+	a.Bio = strings.TrimSpace(a.Bio)
+	if a.Name == "" {
+		return errors.New("name is required")
+	}
+	if a.Slug == "" {
+		a.Slug = slug.Make(a.Name)
+	}
+	if !slug.Valid(a.Slug) {
+		return ErrInvalidAuthorSlug
+	}
+	return nil
+}
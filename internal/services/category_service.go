@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+	"monoex_backend/internal/slug"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCategorySlug = errors.New("category slug may contain only lowercase latin letters, digits and single hyphens (max 80 characters)")
+	ErrCategoryTaken       = errors.New("category slug is already used")
+)
+
+type CategoryService struct {
+	db    *sql.DB
+	repo  *repositories.CategoryRepository
+	audit *AuditService
+}
+
+func NewCategoryService(db *sql.DB, audit *AuditService) *CategoryService {
+	return &CategoryService{db: db, repo: repositories.NewCategoryRepository(db), audit: audit}
+}
+
+// Create создаёт рубрику; пустой slug строится из названия
+func (s *CategoryService) Create(ctx context.Context, c *models.Category) error {
+	if err := prepareCategory(c); err != nil {
+		return err
+	}
+	err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, c); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditCreate, models.EntityCategory, c.ID, nil, c)
+	})
+	if repositories.IsUniqueViolation(err) {
+		return ErrCategoryTaken
+	}
+	return err
+}
+
+// List возвращает все рубрики с числом опубликованных новостей
+func (s *CategoryService) List(ctx context.Context) ([]*models.Category, error) {
+	return s.repo.List(ctx, time.Now().UTC())
+}
+
+func (s *CategoryService) GetByID(ctx context.Context, id int) (*models.Category, error) {
+	category, err := s.repo.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return category, err
+}
+
+func (s *CategoryService) Update(ctx context.Context, c *models.Category) error {
+	if err := prepareCategory(c); err != nil {
+		return err
+	}
+	err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, c.ID)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, c); err != nil {
+			return err
+		}
+		after, err := s.repo.GetByID(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+		*c = *after
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityCategory, c.ID, before, after)
+	})
+	if repositories.IsUniqueViolation(err) {
+		return ErrCategoryTaken
+	}
+	return err
+}
+
+// Delete удаляет рубрику; новости остаются, теряя только эту рубрику
+func (s *CategoryService) Delete(ctx context.Context, id int) error {
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditDelete, models.EntityCategory, id, before, nil)
+	})
+}
+
+func prepareCategory(c *models.Category) error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Description = strings.TrimSpace(c.Description)
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if c.Slug == "" {
+		c.Slug = slug.Make(c.Name)
+	}
+	if !slug.Valid(c.Slug) {
+		return ErrInvalidCategorySlug
+	}
+	return nil
+}
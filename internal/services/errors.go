@@ -0,0 +1,9 @@
+package services
+
+import "errors"
+
+// Общие ошибки сервисов; хендлеры переводят их в 403 и 404
+var (
+	ErrForbidden = errors.New("forbidden")
+	ErrNotFound  = errors.New("not found")
+)
@@ -3,17 +3,22 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"monoex_backend/internal/models"
 	"monoex_backend/internal/repositories"
+	"time"
 )
 
 type LegislationService struct {
-	repo *repositories.LegislationRepository
+	db        *sql.DB
+	repo      *repositories.LegislationRepository
+	audit     *AuditService
+	revisions *RevisionService
 }
 
-func NewLegislationService(repo *repositories.LegislationRepository) *LegislationService {
-	return &LegislationService{repo: repo}
+func NewLegislationService(db *sql.DB, repo *repositories.LegislationRepository, audit *AuditService, revisions *RevisionService) *LegislationService {
+	return &LegislationService{db: db, repo: repo, audit: audit, revisions: revisions}
 }
 
 // Create new legislation
@@ -21,7 +26,18 @@ func (s *LegislationService) Create(ctx context.Context, l *models.Legislation)
 	if l.Title == "" {
 		return errors.New("title is required")
 	}
-	return s.repo.Create(ctx, l)
+	if err := normalizeSEO(&l.SEOFields); err != nil {
+		return err
+	}
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, l); err != nil {
+			return err
+		}
+		if err := s.revisions.Record(ctx, models.EntityLegislation, l.ID, l); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditCreate, models.EntityLegislation, l.ID, nil, l)
+	})
 }
 
 // Get by ID
@@ -49,15 +65,94 @@ func (s *LegislationService) Update(ctx context.Context, l *models.Legislation)
 	if l.ID == 0 {
 		return errors.New("id is required for update")
 	}
-	return s.repo.Update(ctx, l)
+	if err := normalizeSEO(&l.SEOFields); err != nil {
+		return err
+	}
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		// GetByID репозитория законов возвращает nil, nil, если записи нет
+		before, err := s.repo.GetByID(ctx, l.ID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return ErrNotFound
+		}
+		if err := s.repo.Update(ctx, l); err != nil {
+			return err
+		}
+
+		after, err := s.repo.GetByID(ctx, l.ID)
+		if err != nil {
+			return err
+		}
+		*l = *after
+		if err := s.revisions.Record(ctx, models.EntityLegislation, l.ID, after); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityLegislation, l.ID, before, after)
+	})
+}
+
+// RestoreRevision возвращает содержимое старой ревизии как новую ревизию
+func (s *LegislationService) RestoreRevision(ctx context.Context, id, revision int) (*models.Legislation, error) {
+	var restored *models.Legislation
+	err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		current, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return ErrNotFound
+		}
+
+		rev, err := s.revisions.Get(ctx, models.EntityLegislation, id, revision)
+		if err != nil {
+			return err
+		}
+		var old models.Legislation
+		if err := json.Unmarshal(rev.Content, &old); err != nil {
+			return err
+		}
+
+		restored = current
+		restored.Title = old.Title
+		restored.Description = old.Description
+		restored.FilePath = old.FilePath
+		restored.SEOFields = old.SEOFields
+		return s.Update(ctx, restored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
 }
 
-// Delete by ID
+// Delete переносит запись в корзину (восстановить или удалить окончательно — TrashService)
 func (s *LegislationService) Delete(ctx context.Context, id int) error {
 	if id == 0 {
 		return errors.New("id is required")
 	}
-	return s.repo.Delete(ctx, id)
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return ErrNotFound
+		}
+		if err := s.repo.SoftDelete(ctx, id, time.Now().UTC()); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditDelete, models.EntityLegislation, id, before, nil)
+	})
+}
+
+// RecordUpload фиксирует в журнале загрузку PDF закона
+func (s *LegislationService) RecordUpload(ctx context.Context, url string) error {
+	return s.audit.Record(ctx, models.AuditUpload, models.EntityFile, 0, nil, map[string]string{
+		"entity_type": models.EntityLegislation,
+		"url":         url,
+	})
 }
 
 // Get total count
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"monoex_backend/internal/config"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTooManyAttempts = errors.New("too many failed login attempts")
+	ErrLockoutNotFound = errors.New("lockout not found")
+)
+
+// LoginThrottledError сообщает, через сколько можно повторить вход
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %d seconds", ErrTooManyAttempts, int(e.RetryAfter.Seconds()))
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// LoginGuard считает неудачные входы по логину и по IP в Postgres:
+// после free_attempts ошибок каждая следующая удваивает задержку,
+// после max_attempts ключ блокируется на lockout_minutes.
+type LoginGuard struct {
+	repo *repositories.LoginAttemptRepository
+	cfg  config.LockoutConfig
+	now  func() time.Time
+}
+
+func NewLoginGuard(db *sql.DB, cfg config.LockoutConfig) *LoginGuard {
+	return &LoginGuard{
+		repo: repositories.NewLoginAttemptRepository(db),
+		cfg:  cfg,
+		now:  func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Check возвращает LoginThrottledError, если логин или IP сейчас заблокированы
+func (g *LoginGuard) Check(ctx context.Context, username, ip string) error {
+	now := g.now()
+	until, err := g.repo.BlockedUntil(ctx, now, attemptKeys(username, ip))
+	if err != nil {
+		return err
+	}
+	if until != nil {
+		return &LoginThrottledError{RetryAfter: until.Sub(now).Round(time.Second)}
+	}
+	return nil
+}
+
+// Failure фиксирует неудачный вход и выставляет задержку или блокировку
+func (g *LoginGuard) Failure(ctx context.Context, username, ip string) error {
+	now := g.now()
+	lockout := time.Duration(g.cfg.LockoutMinutes) * time.Minute
+
+	for scope, key := range attemptKeys(username, ip) {
+		if key == "" {
+			continue
+		}
+
+		failures, err := g.repo.RegisterFailure(ctx, scope, key, now, now.Add(-lockout))
+		if err != nil {
+			return err
+		}
+
+		if delay := g.delay(failures); delay > 0 {
+			if err := g.repo.SetBlockedUntil(ctx, scope, key, now.Add(delay)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Success сбрасывает счётчики логина и IP
+func (g *LoginGuard) Success(ctx context.Context, username, ip string) error {
+	for scope, key := range attemptKeys(username, ip) {
+		if err := g.repo.Delete(ctx, scope, key); err != nil && err != sql.ErrNoRows {
+			return err
+		}
+	}
+	return nil
+}
+
+// List — счётчики и блокировки для админского просмотра
+func (g *LoginGuard) List(ctx context.Context, limit, offset int) ([]*models.LoginAttempt, int, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	attempts, err := g.repo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := g.repo.GetTotalCount(ctx)
+	return attempts, total, err
+}
+
+// Clear снимает блокировку вручную
+func (g *LoginGuard) Clear(ctx context.Context, scope, key string) error {
+	if scope == models.LoginScopeUsername {
+		key = strings.ToLower(key)
+	}
+	err := g.repo.Delete(ctx, scope, key)
+	if err == sql.ErrNoRows {
+		return ErrLockoutNotFound
+	}
+	return err
+}
+
+// delay: 0 для первых free_attempts ошибок, затем base, 2*base, 4*base… (не больше max),
+// начиная с max_attempts — полная блокировка
+func (g *LoginGuard) delay(failures int) time.Duration {
+	if failures >= g.cfg.MaxAttempts {
+		return time.Duration(g.cfg.LockoutMinutes) * time.Minute
+	}
+	if failures <= g.cfg.FreeAttempts {
+		return 0
+	}
+
+	delay := time.Duration(g.cfg.BackoffBaseSeconds) * time.Second
+	maxDelay := time.Duration(g.cfg.BackoffMaxSeconds) * time.Second
+	for i := g.cfg.FreeAttempts + 1; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// Логин храним в нижнем регистре, чтобы "Admin" и "admin" считались вместе
+func attemptKeys(username, ip string) map[string]string {
+	return map[string]string{
+		models.LoginScopeUsername: strings.ToLower(username),
+		models.LoginScopeIP:       ip,
+	}
+}
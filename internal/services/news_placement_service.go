@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+	"time"
+)
+
+var ErrInvalidOrder = errors.New("ids must be a non-empty list of distinct pinned or featured news ids (max 200)")
+
+const (
+	// Сколько новостей можно упорядочить одним запросом
+	maxReorder = 200
+	// Сколько новостей отдаёт /news/featured, если limit не задан, и сколько максимум
+	featuredLimit    = 10
+	maxFeaturedLimit = 50
+)
+
+// GetFeatured возвращает новости блока «главное» на языке language, в ручном порядке
+func (s *NewsService) GetFeatured(ctx context.Context, language string, limit int) ([]*models.News, error) {
+	if limit <= 0 {
+		limit = featuredLimit
+	}
+	if limit > maxFeaturedLimit {
+		limit = maxFeaturedLimit
+	}
+	news, err := s.repo.GetFeatured(ctx, time.Now().UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.LoadTaxonomy(ctx, news); err != nil {
+		return nil, err
+	}
+	return news, s.localize(ctx, news, language, false)
+}
+
+// SetPlacement закрепляет новость и ставит её в «главное» (до FeaturedUntil) или убирает оттуда
+func (s *NewsService) SetPlacement(ctx context.Context, id int, p models.NewsPlacement) error {
+	if !p.Featured {
+		p.FeaturedUntil = nil
+	}
+	if p.FeaturedUntil != nil {
+		until := p.FeaturedUntil.UTC()
+		if !until.After(time.Now().UTC()) {
+			return errors.New("featured_until must be in the future")
+		}
+		p.FeaturedUntil = &until
+	}
+
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.UpdatePlacement(ctx, id, p); err != nil {
+			return err
+		}
+		after, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityNews, id, before, after)
+	})
+}
+
+// Reorder задаёт ручной порядок закреплённых и избранных новостей: ids сверху вниз.
+// Остальные закреплённые и избранные сдвигаются следом в прежнем порядке.
+func (s *NewsService) Reorder(ctx context.Context, ids []int) error {
+	if len(ids) == 0 || len(ids) > maxReorder {
+		return ErrInvalidOrder
+	}
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			return ErrInvalidOrder
+		}
+		seen[id] = true
+	}
+
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		placed, err := s.repo.GetPlaced(ctx)
+		if err != nil {
+			return err
+		}
+		before := make(map[int]*models.News, len(placed))
+		for _, n := range placed {
+			before[n.ID] = n
+		}
+		// В списке могут быть только закреплённые и избранные новости
+		for _, id := range ids {
+			if before[id] != nil {
+				continue
+			}
+			if _, err := s.repo.GetByID(ctx, id); err == sql.ErrNoRows {
+				return ErrNotFound
+			} else if err != nil {
+				return err
+			}
+			return ErrInvalidOrder
+		}
+
+		if _, err := s.repo.Reorder(ctx, ids); err != nil {
+			return err
+		}
+
+		// В журнал попадают все новости, чьё место изменилось, включая сдвинутые
+		after, err := s.repo.GetPlaced(ctx)
+		if err != nil {
+			return err
+		}
+		for _, a := range after {
+			b := before[a.ID]
+			if b == nil || b.Priority == a.Priority {
+				continue
+			}
+			if err := s.audit.Record(ctx, models.AuditUpdate, models.EntityNews, a.ID, b, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
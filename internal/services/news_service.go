@@ -3,17 +3,69 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"monoex_backend/internal/config"
 	"monoex_backend/internal/models"
 	"monoex_backend/internal/repositories"
+	"monoex_backend/internal/slug"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidStatus       = errors.New("invalid news status, expected draft, in_review, published or archived")
+	ErrInvalidTransition   = errors.New("news status transition is not allowed")
+	ErrInvalidLink         = errors.New("link may contain only lowercase latin letters, digits and single hyphens (max 80 characters)")
+	ErrLinkTaken           = errors.New("link is already used by another news")
+	ErrUnknownCategory     = errors.New("unknown category")
+	ErrInvalidTag          = errors.New("tag must contain at least one letter or digit")
+	ErrUnknownAuthor       = errors.New("unknown author")
+	ErrScheduleNeedsReview = errors.New("publish_at can be set only on news in review or published")
+	ErrEmptyQuery          = errors.New("search query is required")
+)
+
+const (
+	// Сколько новостей планировщик обрабатывает в одной транзакции
+	scheduleBatchSize = 100
+	// Сколько раз подбирать ссылку заново при гонке за одну и ту же
+	linkAttempts = 3
 )
 
 type NewsService struct {
-	repo *repositories.NewsRepository
+	db           *sql.DB
+	repo         *repositories.NewsRepository
+	categories   *repositories.CategoryRepository
+	tags         *repositories.TagRepository
+	authors      *repositories.AuthorRepository
+	translations *repositories.NewsTranslationRepository
+	audit        *AuditService
+	revisions    *RevisionService
+	i18n         config.I18nConfig
 }
 
-func NewNewsService(repo *repositories.NewsRepository) *NewsService {
-	return &NewsService{repo: repo}
+func NewNewsService(db *sql.DB, repo *repositories.NewsRepository, audit *AuditService, revisions *RevisionService, i18n config.I18nConfig) *NewsService {
+	s := &NewsService{
+		db:           db,
+		repo:         repo,
+		categories:   repositories.NewCategoryRepository(db),
+		tags:         repositories.NewTagRepository(db),
+		authors:      repositories.NewAuthorRepository(db),
+		translations: repositories.NewNewsTranslationRepository(db),
+		audit:        audit,
+		revisions:    revisions,
+		i18n:         i18n,
+	}
+	// Язык по умолчанию поддерживается всегда; неизвестный резервный язык заменяем на него
+	if !s.supportsLanguage(i18n.DefaultLanguage) {
+		s.i18n.Languages = append([]string{i18n.DefaultLanguage}, i18n.Languages...)
+	}
+	if !s.supportsLanguage(i18n.FallbackLanguage) {
+		s.i18n.FallbackLanguage = i18n.DefaultLanguage
+	}
+	return s
 }
 
 // Create new news
@@ -22,9 +74,83 @@ func (s *NewsService) Create(ctx context.Context, n *models.News) error {
 		return errors.New("title is required")
 	}
 	if n.Status == "" {
-		n.Status = "draft"
+		n.Status = models.NewsDraft
+	}
+	// Новость начинает путь с черновика, ревью или сразу с публикации
+	if !models.IsValidNewsStatus(n.Status) || n.Status == models.NewsArchived {
+		return ErrInvalidStatus
+	}
+	// Сразу опубликовать или запланировать публикацию может только тот, у кого есть право публикации
+	scheduled := n.PublishAt != nil || n.UnpublishAt != nil
+	if (n.Status == models.NewsPublished || scheduled) && !HasPermission(ctx, models.PermNewsPublish) {
+		return ErrForbidden
+	}
+	if err := normalizeSchedule(&n.PublishAt, &n.UnpublishAt); err != nil {
+		return err
+	}
+	if err := checkScheduleStatus(n.Status, n.PublishAt); err != nil {
+		return err
+	}
+	if err := normalizeSEO(&n.SEOFields); err != nil {
+		return err
+	}
+
+	// Без рубрик и тегов — пустые списки, чтобы ответ был одинаковым с GET
+	if n.Categories == nil {
+		n.Categories = []string{}
+	}
+	if n.Tags == nil {
+		n.Tags = []string{}
+	}
+	// Авторы не указаны — подписываем автором, связанным с админом (не с API-ключом)
+	if n.Authors == nil {
+		n.Authors = []string{}
+		if admin := AdminFromContext(ctx); admin != nil && admin.APIKeyID == 0 {
+			author, err := s.authors.GetByAdmin(ctx, admin.ID)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			if author != nil {
+				n.Authors = []string{author.Slug}
+			}
+		}
+	}
+
+	// Пустая ссылка — генерируем из заголовка. Если параллельный запрос
+	// успел занять ту же ссылку, подбираем следующую.
+	generated := n.Link == ""
+	if !generated && !slug.Valid(n.Link) {
+		return ErrInvalidLink
+	}
+	for attempt := 1; ; attempt++ {
+		if generated {
+			link, err := s.uniqueLink(ctx, n.Title)
+			if err != nil {
+				return err
+			}
+			n.Link = link
+		}
+
+		err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+			if err := s.repo.Create(ctx, n, time.Now().UTC()); err != nil {
+				return err
+			}
+			if err := s.setTaxonomy(ctx, n); err != nil {
+				return err
+			}
+			if err := s.revisions.Record(ctx, models.EntityNews, n.ID, n); err != nil {
+				return err
+			}
+			return s.audit.Record(ctx, models.AuditCreate, models.EntityNews, n.ID, nil, n)
+		})
+		if repositories.IsUniqueViolation(err) {
+			if generated && attempt < linkAttempts {
+				continue
+			}
+			return ErrLinkTaken
+		}
+		return err
 	}
-	return s.repo.Create(ctx, n)
 }
 
 // Get by ID
@@ -36,35 +162,144 @@ func (s *NewsService) GetByID(ctx context.Context, id int) (*models.News, error)
 		}
 		return nil, err
 	}
+	if err := s.repo.LoadTaxonomy(ctx, []*models.News{news}); err != nil {
+		return nil, err
+	}
+	if err := s.markMissing(ctx, []*models.News{news}); err != nil {
+		return nil, err
+	}
 	return news, nil
 }
 
-// Get by link (only published)
-func (s *NewsService) GetByLink(ctx context.Context, link string) (*models.News, error) {
-	news, err := s.repo.GetByLink(ctx, link)
-	if err != nil {
-		if err == sql.ErrNoRows {
+// Get by link (only published) на языке language.
+// Ссылка перевода открывает новость на языке этого перевода.
+func (s *NewsService) GetByLink(ctx context.Context, link, language string) (*models.News, error) {
+	now := time.Now().UTC()
+	news, err := s.repo.GetByLink(ctx, link, now)
+	if err == sql.ErrNoRows {
+		t, terr := s.translations.GetPublishedByLink(ctx, link, language, now)
+		if terr == sql.ErrNoRows {
 			return nil, nil
 		}
+		if terr != nil {
+			return nil, terr
+		}
+		language = t.Language
+		news, err = s.repo.GetByID(ctx, t.NewsID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.LoadTaxonomy(ctx, []*models.News{news}); err != nil {
+		return nil, err
+	}
+	if err := s.localize(ctx, []*models.News{news}, language, false); err != nil {
 		return nil, err
 	}
 	return news, nil
 }
 
+// Preview возвращает новость в любом статусе на языке language, включая неопубликованные переводы
+func (s *NewsService) Preview(ctx context.Context, id int, language string) (*models.News, error) {
+	news, err := s.repo.GetByID(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.LoadTaxonomy(ctx, []*models.News{news}); err != nil {
+		return nil, err
+	}
+	if err := s.localize(ctx, []*models.News{news}, language, true); err != nil {
+		return nil, err
+	}
+	return news, nil
+}
+
+// CurrentLink возвращает текущую ссылку опубликованной новости по прежней.
+// Пустая строка — такой прежней ссылки нет.
+func (s *NewsService) CurrentLink(ctx context.Context, retired string) (string, error) {
+	news, err := s.repo.GetByRetiredLink(ctx, retired, time.Now().UTC())
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return news.Link, nil
+}
+
 // Get all news with pagination
 func (s *NewsService) GetAll(ctx context.Context, limit, offset int) ([]*models.News, error) {
 	if limit <= 0 {
 		limit = 10
 	}
-	return s.repo.GetAll(ctx, limit, offset)
+	news, err := s.repo.GetAll(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.LoadTaxonomy(ctx, news); err != nil {
+		return nil, err
+	}
+	return news, s.markMissing(ctx, news)
+}
+
+// Get published news with pagination, filtered by category and tag, на языке language
+func (s *NewsService) GetPublished(ctx context.Context, f models.NewsFilter, language string, limit, offset int) ([]*models.News, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	news, err := s.repo.GetPublished(ctx, time.Now().UTC(), f, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.LoadTaxonomy(ctx, news); err != nil {
+		return nil, err
+	}
+	return news, s.localize(ctx, news, language, false)
 }
 
-// Get published news with pagination
-func (s *NewsService) GetPublished(ctx context.Context, limit, offset int) ([]*models.News, error) {
+// Search ищет среди опубликованных новостей (public)
+func (s *NewsService) Search(ctx context.Context, q string, limit, offset int) ([]*models.NewsSearchResult, int, error) {
+	now := time.Now().UTC()
+	return s.search(ctx, q, models.NewsSearchFilter{Published: &now}, limit, offset)
+}
+
+// SearchAll ищет по всем новостям; status — необязательный фильтр (admin)
+func (s *NewsService) SearchAll(ctx context.Context, q, status string, limit, offset int) ([]*models.NewsSearchResult, int, error) {
+	if status != "" && !models.IsValidNewsStatus(status) {
+		return nil, 0, ErrInvalidStatus
+	}
+	return s.search(ctx, q, models.NewsSearchFilter{Status: status}, limit, offset)
+}
+
+func (s *NewsService) search(ctx context.Context, q string, f models.NewsSearchFilter, limit, offset int) ([]*models.NewsSearchResult, int, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, 0, ErrEmptyQuery
+	}
 	if limit <= 0 {
 		limit = 10
 	}
-	return s.repo.GetPublished(ctx, limit, offset)
+
+	results, err := s.repo.Search(ctx, q, f, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.repo.SearchCount(ctx, q, f)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	news := make([]*models.News, len(results))
+	for i, res := range results {
+		news[i] = res.News
+	}
+	if err := s.repo.LoadTaxonomy(ctx, news); err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
 }
 
 // Update existing news
@@ -72,7 +307,96 @@ func (s *NewsService) Update(ctx context.Context, n *models.News) error {
 	if n.ID == 0 {
 		return errors.New("id is required for update")
 	}
-	return s.repo.Update(ctx, n)
+	if err := normalizeSEO(&n.SEOFields); err != nil {
+		return err
+	}
+
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, n.ID)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.LoadTaxonomy(ctx, []*models.News{before}); err != nil {
+			return err
+		}
+		// Пустой статус — оставить как есть
+		if n.Status == "" {
+			n.Status = before.Status
+		}
+		if err := checkTransition(ctx, before.Status, n.Status); err != nil {
+			return err
+		}
+		// Пустая ссылка — оставить как есть
+		if n.Link == "" {
+			n.Link = before.Link
+		}
+		if n.Link != before.Link && !slug.Valid(n.Link) {
+			return ErrInvalidLink
+		}
+		// Расписание: nil — оставить как есть (снять — через /news/{id}/schedule)
+		if n.PublishAt == nil {
+			n.PublishAt = before.PublishAt
+		}
+		if n.UnpublishAt == nil {
+			n.UnpublishAt = before.UnpublishAt
+		}
+		if n.Status != before.Status {
+			n.PublishAt, n.UnpublishAt = scheduleAfterTransition(n.Status, n.PublishAt, n.UnpublishAt)
+		}
+		if err := normalizeSchedule(&n.PublishAt, &n.UnpublishAt); err != nil {
+			return err
+		}
+		if !sameTime(n.PublishAt, before.PublishAt) {
+			if err := checkScheduleStatus(n.Status, n.PublishAt); err != nil {
+				return err
+			}
+		}
+		if (!sameTime(n.PublishAt, before.PublishAt) || !sameTime(n.UnpublishAt, before.UnpublishAt)) &&
+			!HasPermission(ctx, models.PermNewsPublish) {
+			return ErrForbidden
+		}
+		// Правка текста запланированной новости без права публикации снимает плановую
+		// публикацию: новый текст должен снова одобрить publisher
+		if n.PublishAt != nil && n.Status != models.NewsPublished && contentChanged(before, n) &&
+			!HasPermission(ctx, models.PermNewsPublish) {
+			n.PublishAt = nil
+		}
+		// Прежняя ссылка уходит в историю и продолжает вести на новость
+		if n.Link != before.Link {
+			if err := s.repo.ReleaseLink(ctx, n.ID, n.Link); err != nil {
+				return err
+			}
+			if err := s.repo.RetireLink(ctx, n.ID, before.Link); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.Update(ctx, n, time.Now().UTC()); err != nil {
+			if repositories.IsUniqueViolation(err) {
+				return ErrLinkTaken
+			}
+			return err
+		}
+		// Рубрики, теги и авторы: nil — оставить как есть, [] — очистить
+		if err := s.setTaxonomy(ctx, n); err != nil {
+			return err
+		}
+
+		after, err := s.repo.GetByID(ctx, n.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.LoadTaxonomy(ctx, []*models.News{after}); err != nil {
+			return err
+		}
+		*n = *after
+		if err := s.revisions.Record(ctx, models.EntityNews, n.ID, after); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityNews, n.ID, before, after)
+	})
 }
 
 // Update only status
@@ -83,15 +407,175 @@ func (s *NewsService) UpdateStatus(ctx context.Context, id int, status string) e
 	if status == "" {
 		return errors.New("status is required")
 	}
-	return s.repo.UpdateStatus(ctx, id, status)
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkTransition(ctx, before.Status, status); err != nil {
+			return err
+		}
+		if status == before.Status {
+			return nil
+		}
+		if err := s.repo.UpdateStatus(ctx, id, status, time.Now().UTC()); err != nil {
+			return err
+		}
+
+		publishAt, unpublishAt := scheduleAfterTransition(status, before.PublishAt, before.UnpublishAt)
+		if !sameTime(publishAt, before.PublishAt) || !sameTime(unpublishAt, before.UnpublishAt) {
+			if err := s.repo.UpdateSchedule(ctx, id, publishAt, unpublishAt); err != nil {
+				return err
+			}
+		}
+
+		after, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, statusAction(before.Status, status), models.EntityNews, id, before, after)
+	})
+}
+
+// RestoreRevision возвращает текст старой ревизии как новую ревизию.
+// Восстанавливается содержимое; ссылка, статус и расписание остаются текущими.
+func (s *NewsService) RestoreRevision(ctx context.Context, id, revision int) (*models.News, error) {
+	var restored *models.News
+	err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		current, err := s.repo.GetByID(ctx, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		rev, err := s.revisions.Get(ctx, models.EntityNews, id, revision)
+		if err != nil {
+			return err
+		}
+		var old models.News
+		if err := json.Unmarshal(rev.Content, &old); err != nil {
+			return err
+		}
+
+		restored = current
+		restored.Title = old.Title
+		restored.Description = old.Description
+		restored.FullText = old.FullText
+		restored.ImagePath = old.ImagePath
+		restored.SEOFields = old.SEOFields
+		return s.Update(ctx, restored)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// Schedule задаёт время автопубликации и автоснятия; nil снимает расписание
+func (s *NewsService) Schedule(ctx context.Context, id int, publishAt, unpublishAt *time.Time) error {
+	if err := normalizeSchedule(&publishAt, &unpublishAt); err != nil {
+		return err
+	}
+
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkScheduleStatus(before.Status, publishAt); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateSchedule(ctx, id, publishAt, unpublishAt); err != nil {
+			return err
+		}
+		after, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityNews, id, before, after)
+	})
+}
+
+// ApplySchedule публикует и снимает новости, у которых наступило время.
+// Безопасно вызывать с нескольких экземпляров: строки берутся FOR UPDATE SKIP LOCKED.
+// Возвращает число обработанных новостей.
+func (s *NewsService) ApplySchedule(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		processed := 0
+		err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+			due, err := s.repo.LockDue(ctx, time.Now().UTC(), scheduleBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, before := range due {
+				// Наступил publish_at — публикуем (временем публикации считаем publish_at,
+				// даже если планировщик опоздал), наступил unpublish_at — в архив
+				status, at := models.NewsPublished, time.Now().UTC()
+				if before.Status == models.NewsPublished {
+					status = models.NewsArchived
+				} else if before.PublishAt != nil {
+					at = *before.PublishAt
+				}
+				if err := s.repo.UpdateStatus(ctx, before.ID, status, at); err != nil {
+					return err
+				}
+				after, err := s.repo.GetByID(ctx, before.ID)
+				if err != nil {
+					return err
+				}
+				// Актёра в контексте нет — в журнале это системное действие
+				if err := s.audit.Record(ctx, statusAction(before.Status, status), models.EntityNews, before.ID, before, after); err != nil {
+					return err
+				}
+			}
+			processed = len(due)
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+		total += processed
+		if processed < scheduleBatchSize {
+			return total, nil
+		}
+	}
 }
 
-// Delete by ID
+// Delete переносит запись в корзину (восстановить или удалить окончательно — TrashService)
 func (s *NewsService) Delete(ctx context.Context, id int) error {
 	if id == 0 {
 		return errors.New("id is required")
 	}
-	return s.repo.Delete(ctx, id)
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SoftDelete(ctx, id, time.Now().UTC()); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditDelete, models.EntityNews, id, before, nil)
+	})
+}
+
+// RecordUpload фиксирует в журнале загрузку изображения новости
+func (s *NewsService) RecordUpload(ctx context.Context, url string) error {
+	return s.audit.Record(ctx, models.AuditUpload, models.EntityFile, 0, nil, map[string]string{
+		"entity_type": models.EntityNews,
+		"url":         url,
+	})
 }
 
 // Get total count
@@ -99,7 +583,242 @@ func (s *NewsService) GetTotalCount(ctx context.Context) (int, error) {
 	return s.repo.GetTotalCount(ctx)
 }
 
-// Get published count
-func (s *NewsService) GetPublishedCount(ctx context.Context) (int, error) {
-	return s.repo.GetPublishedCount(ctx)
+// Get published count (с теми же фильтрами, что и GetPublished)
+func (s *NewsService) GetPublishedCount(ctx context.Context, f models.NewsFilter) (int, error) {
+	return s.repo.GetPublishedCount(ctx, time.Now().UTC(), f)
+}
+
+// SitemapPages возвращает lastmod каждого файла sitemap по size страниц
+func (s *NewsService) SitemapPages(ctx context.Context, size int) ([]time.Time, error) {
+	return s.repo.SitemapPages(ctx, time.Now().UTC(), s.translatedLanguages(), size)
+}
+
+// SitemapLinks возвращает страницы файла sitemap номер page (с 1)
+func (s *NewsService) SitemapLinks(ctx context.Context, page, size int) ([]models.NewsPage, error) {
+	return s.repo.SitemapLinks(ctx, time.Now().UTC(), s.translatedLanguages(), size, (page-1)*size)
+}
+
+// statusAction: переход в published — publish, из published — unpublish
+func statusAction(from, to string) string {
+	switch {
+	case to == models.NewsPublished:
+		return models.AuditPublish
+	case from == models.NewsPublished:
+		return models.AuditUnpublish
+	default:
+		return models.AuditUpdate
+	}
+}
+
+// checkTransition проверяет переход статуса и право на него:
+// публиковать и снимать с публикации может только publisher
+func checkTransition(ctx context.Context, from, to string) error {
+	if !models.IsValidNewsStatus(to) {
+		return ErrInvalidStatus
+	}
+	if from == to {
+		return nil
+	}
+	if !models.CanTransitionNews(from, to) {
+		return fmt.Errorf("%w: %s → %s", ErrInvalidTransition, from, to)
+	}
+	if (from == models.NewsPublished || to == models.NewsPublished) && !HasPermission(ctx, models.PermNewsPublish) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// scheduleAfterTransition: ручная смена статуса отменяет расписание, которое вернуло бы
+// прежний: уход из публикации — отложенную публикацию, публикация — прошедшее время снятия
+func scheduleAfterTransition(status string, publishAt, unpublishAt *time.Time) (*time.Time, *time.Time) {
+	if status == models.NewsPublished {
+		if unpublishAt != nil && !unpublishAt.After(time.Now().UTC()) {
+			unpublishAt = nil
+		}
+		return publishAt, unpublishAt
+	}
+	if status != models.NewsInReview {
+		publishAt = nil
+	}
+	return publishAt, unpublishAt
+}
+
+// setTaxonomy заменяет рубрики, теги и авторов новости, если они переданы (не nil).
+// Рубрики и авторы должны существовать; теги создаются по названию при первом назначении.
+func (s *NewsService) setTaxonomy(ctx context.Context, n *models.News) error {
+	if n.Categories != nil {
+		slugs := uniqueCategorySlugs(n.Categories)
+		found, err := s.categories.GetBySlugs(ctx, slugs)
+		if err != nil {
+			return err
+		}
+		ids := make([]int, 0, len(found))
+		known := make(map[string]bool, len(found))
+		for _, c := range found {
+			ids = append(ids, c.ID)
+			known[c.Slug] = true
+		}
+		var missing []string
+		for _, v := range slugs {
+			if !known[v] {
+				missing = append(missing, v)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %s", ErrUnknownCategory, strings.Join(missing, ", "))
+		}
+		if err := s.repo.SetCategories(ctx, n.ID, ids); err != nil {
+			return err
+		}
+		n.Categories = slugs
+	}
+
+	if n.Tags != nil {
+		names := make(map[string]string, len(n.Tags))
+		for _, name := range n.Tags {
+			name = strings.TrimSpace(name)
+			tagSlug := slug.Make(name)
+			if tagSlug == "" {
+				return ErrInvalidTag
+			}
+			if _, ok := names[tagSlug]; !ok {
+				names[tagSlug] = name
+			}
+		}
+		slugs := make([]string, 0, len(names))
+		for tagSlug := range names {
+			slugs = append(slugs, tagSlug)
+		}
+		sort.Strings(slugs)
+
+		ids := make([]int, 0, len(slugs))
+		for _, tagSlug := range slugs {
+			tag := &models.Tag{Slug: tagSlug, Name: names[tagSlug]}
+			if err := s.tags.Ensure(ctx, tag); err != nil {
+				return err
+			}
+			ids = append(ids, tag.ID)
+		}
+		if err := s.repo.SetTags(ctx, n.ID, ids); err != nil {
+			return err
+		}
+		n.Tags = slugs
+	}
+
+	if n.Authors != nil {
+		// Порядок авторов — порядок подписи, поэтому только убираем повторы, не сортируя
+		slugs := []string{}
+		seen := make(map[string]bool, len(n.Authors))
+		for _, v := range n.Authors {
+			v = strings.ToLower(strings.TrimSpace(v))
+			if v != "" && !seen[v] {
+				seen[v] = true
+				slugs = append(slugs, v)
+			}
+		}
+		found, err := s.authors.GetBySlugs(ctx, slugs)
+		if err != nil {
+			return err
+		}
+		bySlug := make(map[string]*models.Author, len(found))
+		for _, a := range found {
+			bySlug[a.Slug] = a
+		}
+		ids := make([]int, 0, len(slugs))
+		bylines := make([]models.Byline, 0, len(slugs))
+		var missing []string
+		for _, v := range slugs {
+			a, ok := bySlug[v]
+			if !ok {
+				missing = append(missing, v)
+				continue
+			}
+			ids = append(ids, a.ID)
+			bylines = append(bylines, models.Byline{ID: a.ID, Slug: a.Slug, Name: a.Name, Position: a.Position, PhotoPath: a.PhotoPath})
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %s", ErrUnknownAuthor, strings.Join(missing, ", "))
+		}
+		if err := s.repo.SetAuthors(ctx, n.ID, ids); err != nil {
+			return err
+		}
+		n.Authors, n.Bylines = slugs, bylines
+	}
+	return nil
+}
+
+// uniqueCategorySlugs приводит slug'и к нижнему регистру и убирает повторы
+func uniqueCategorySlugs(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := []string{}
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// uniqueLink строит ссылку из заголовка: "title", при занятости — "title-2", "title-3"…
+func (s *NewsService) uniqueLink(ctx context.Context, title string) (string, error) {
+	base := slug.Make(title)
+	if base == "" {
+		base = "news"
+	}
+
+	taken, err := s.repo.LinksWithBase(ctx, base)
+	if err != nil {
+		return "", err
+	}
+	used := make(map[string]bool, len(taken))
+	for _, link := range taken {
+		used[link] = true
+	}
+
+	link := base
+	for n := 2; used[link]; n++ {
+		link = slug.WithSuffix(base, n)
+	}
+	return link, nil
+}
+
+// normalizeSchedule приводит время к UTC и проверяет, что снятие позже публикации
+func normalizeSchedule(publishAt, unpublishAt **time.Time) error {
+	for _, t := range []**time.Time{publishAt, unpublishAt} {
+		if *t != nil {
+			utc := (*t).UTC()
+			*t = &utc
+		}
+	}
+	if *publishAt != nil && *unpublishAt != nil && !(*unpublishAt).After(**publishAt) {
+		return errors.New("unpublish_at must be after publish_at")
+	}
+	return nil
+}
+
+// checkScheduleStatus: по расписанию публикуются только новости на ревью —
+// черновик сначала отправляют на ревью, архив возвращают в черновики
+func checkScheduleStatus(status string, publishAt *time.Time) error {
+	if publishAt != nil && (status == models.NewsDraft || status == models.NewsArchived) {
+		return ErrScheduleNeedsReview
+	}
+	return nil
+}
+
+// contentChanged сообщает, что изменилось то, что увидит читатель: текст, изображение или SEO-поля
+func contentChanged(before, after *models.News) bool {
+	return before.Title != after.Title || before.Description != after.Description ||
+		before.FullText != after.FullText || before.ImagePath != after.ImagePath ||
+		before.SEOFields != after.SEOFields
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
 }
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+	"monoex_backend/internal/slug"
+	"strings"
+)
+
+var (
+	ErrUnsupportedLanguage = errors.New("unsupported translation language")
+	ErrTranslationNotFound = errors.New("translation not found")
+)
+
+// Languages — языки сайта; первый не обязательно язык по умолчанию
+func (s *NewsService) Languages() []string {
+	return s.i18n.Languages
+}
+
+// DefaultLanguage — язык основных полей новостей и остального контента
+func (s *NewsService) DefaultLanguage() string {
+	return s.i18n.DefaultLanguage
+}
+
+// FallbackLanguage — язык, если клиент не указал свой или его нет среди поддерживаемых
+func (s *NewsService) FallbackLanguage() string {
+	return s.i18n.FallbackLanguage
+}
+
+// translatedLanguages — языки сайта, кроме языка по умолчанию
+func (s *NewsService) translatedLanguages() []string {
+	var languages []string
+	for _, l := range s.i18n.Languages {
+		if l != s.i18n.DefaultLanguage {
+			languages = append(languages, l)
+		}
+	}
+	return languages
+}
+
+func (s *NewsService) supportsLanguage(language string) bool {
+	for _, l := range s.i18n.Languages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// ListTranslations возвращает все переводы новости и языки, на которые её ещё не перевели
+func (s *NewsService) ListTranslations(ctx context.Context, newsID int) ([]*models.NewsTranslation, []string, error) {
+	news, err := s.repo.GetByID(ctx, newsID)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	translations, err := s.translations.ListByNews(ctx, []int{newsID})
+	if err != nil {
+		return nil, nil, err
+	}
+	if translations == nil {
+		translations = []*models.NewsTranslation{}
+	}
+	if err := s.markMissing(ctx, []*models.News{news}); err != nil {
+		return nil, nil, err
+	}
+	return translations, news.MissingLanguages, nil
+}
+
+// SaveTranslation создаёт или заменяет перевод. Пустая ссылка — оставить прежнюю
+// или построить из заголовка перевода. Публиковать и снимать перевод может только publisher.
+func (s *NewsService) SaveTranslation(ctx context.Context, t *models.NewsTranslation) error {
+	if !s.supportsLanguage(t.Language) || t.Language == s.i18n.DefaultLanguage {
+		return fmt.Errorf("%w: %s (expected one of %s except %s, which is edited on the news itself)",
+			ErrUnsupportedLanguage, t.Language, strings.Join(s.i18n.Languages, ", "), s.i18n.DefaultLanguage)
+	}
+	t.Title = strings.TrimSpace(t.Title)
+	if t.Title == "" {
+		return errors.New("title is required")
+	}
+
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		if _, err := s.repo.GetByID(ctx, t.NewsID); err == sql.ErrNoRows {
+			return ErrNotFound
+		} else if err != nil {
+			return err
+		}
+
+		before, err := s.translations.Get(ctx, t.NewsID, t.Language)
+		if err == sql.ErrNoRows {
+			before = nil
+		} else if err != nil {
+			return err
+		}
+
+		wasPublished := before != nil && before.Published
+		if t.Published != wasPublished && !HasPermission(ctx, models.PermNewsPublish) {
+			return ErrForbidden
+		}
+
+		switch {
+		case t.Link == "" && before != nil:
+			t.Link = before.Link
+		case t.Link == "":
+			link, err := s.uniqueTranslationLink(ctx, t.Language, t.Title)
+			if err != nil {
+				return err
+			}
+			t.Link = link
+		case before == nil || t.Link != before.Link:
+			if !slug.Valid(t.Link) {
+				return ErrInvalidLink
+			}
+			used, err := s.translations.LinkUsedByNews(ctx, t.Link, t.NewsID)
+			if err != nil {
+				return err
+			}
+			if used {
+				return ErrLinkTaken
+			}
+		}
+
+		if err := s.translations.Save(ctx, t); err != nil {
+			if repositories.IsUniqueViolation(err) {
+				return ErrLinkTaken
+			}
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityNews, t.NewsID, before, t)
+	})
+}
+
+// DeleteTranslation удаляет перевод; удалить опубликованный может только publisher
+func (s *NewsService) DeleteTranslation(ctx context.Context, newsID int, language string) error {
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.translations.Get(ctx, newsID, language)
+		if err == sql.ErrNoRows {
+			return ErrTranslationNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if before.Published && !HasPermission(ctx, models.PermNewsPublish) {
+			return ErrForbidden
+		}
+		if err := s.translations.Delete(ctx, newsID, language); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityNews, newsID, before, nil)
+	})
+}
+
+// localize подставляет опубликованный перевод на language, а если его нет —
+// на резервный язык; без обоих остаётся язык по умолчанию. Заполняет Alternates.
+// drafts — учитывать и неопубликованные переводы (предпросмотр).
+func (s *NewsService) localize(ctx context.Context, news []*models.News, language string, drafts bool) error {
+	if len(news) == 0 {
+		return nil
+	}
+	ids := make([]int, len(news))
+	for i, n := range news {
+		ids[i] = n.ID
+	}
+
+	listTranslations := s.translations.ListPublished
+	if drafts {
+		listTranslations = s.translations.ListByNews
+	}
+	translations, err := listTranslations(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byNews := make(map[int]map[string]*models.NewsTranslation)
+	for _, t := range translations {
+		if !s.supportsLanguage(t.Language) || t.Language == s.i18n.DefaultLanguage {
+			continue
+		}
+		if byNews[t.NewsID] == nil {
+			byNews[t.NewsID] = make(map[string]*models.NewsTranslation)
+		}
+		byNews[t.NewsID][t.Language] = t
+	}
+
+	for _, n := range news {
+		n.Language = s.i18n.DefaultLanguage
+		n.Alternates = map[string]string{s.i18n.DefaultLanguage: n.Link}
+		for lang, t := range byNews[n.ID] {
+			n.Alternates[lang] = t.Link
+		}
+
+		for _, candidate := range []string{language, s.i18n.FallbackLanguage} {
+			if candidate == s.i18n.DefaultLanguage {
+				break
+			}
+			if t := byNews[n.ID][candidate]; t != nil {
+				n.Title, n.Description, n.FullText, n.Link = t.Title, t.Description, t.FullText, t.Link
+				// SEO-поля написаны на основном языке: у перевода — его заголовок, описание и ссылка
+				n.MetaTitle, n.MetaDescription, n.CanonicalURL = "", "", ""
+				n.Language = t.Language
+				break
+			}
+		}
+	}
+	return s.localizeBylines(ctx, news)
+}
+
+// localizeBylines переводит имена и должности авторов в подписи на язык новости
+func (s *NewsService) localizeBylines(ctx context.Context, news []*models.News) error {
+	var ids []int
+	for _, n := range news {
+		if n.Language == s.i18n.DefaultLanguage {
+			continue
+		}
+		for _, b := range n.Bylines {
+			ids = append(ids, b.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	translations, err := s.authors.ListTranslations(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byAuthor := make(map[int]map[string]*models.AuthorTranslation)
+	for _, t := range translations {
+		if byAuthor[t.AuthorID] == nil {
+			byAuthor[t.AuthorID] = make(map[string]*models.AuthorTranslation)
+		}
+		byAuthor[t.AuthorID][t.Language] = t
+	}
+	for _, n := range news {
+		for i, b := range n.Bylines {
+			if t := byAuthor[b.ID][n.Language]; t != nil {
+				n.Bylines[i].Name = t.Name
+				if t.Position != "" {
+					n.Bylines[i].Position = t.Position
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// markMissing заполняет MissingLanguages: языки сайта, на которые новость не переведена
+func (s *NewsService) markMissing(ctx context.Context, news []*models.News) error {
+	if len(news) == 0 {
+		return nil
+	}
+	ids := make([]int, len(news))
+	for i, n := range news {
+		ids[i] = n.ID
+	}
+
+	translations, err := s.translations.ListByNews(ctx, ids)
+	if err != nil {
+		return err
+	}
+	translated := make(map[int]map[string]bool)
+	for _, t := range translations {
+		if translated[t.NewsID] == nil {
+			translated[t.NewsID] = make(map[string]bool)
+		}
+		translated[t.NewsID][t.Language] = true
+	}
+
+	for _, n := range news {
+		n.MissingLanguages = []string{}
+		for _, lang := range s.i18n.Languages {
+			if lang != s.i18n.DefaultLanguage && !translated[n.ID][lang] {
+				n.MissingLanguages = append(n.MissingLanguages, lang)
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueTranslationLink строит ссылку перевода из его заголовка, как uniqueLink для новости
+func (s *NewsService) uniqueTranslationLink(ctx context.Context, language, title string) (string, error) {
+	base := slug.Make(title)
+	if base == "" {
+		base = "news"
+	}
+
+	taken, err := s.translations.LinksWithBase(ctx, language, base)
+	if err != nil {
+		return "", err
+	}
+	used := make(map[string]bool, len(taken))
+	for _, link := range taken {
+		used[link] = true
+	}
+
+	link := base
+	for n := 2; used[link]; n++ {
+		link = slug.WithSuffix(base, n)
+	}
+	return link, nil
+}
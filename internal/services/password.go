@@ -0,0 +1,175 @@
+package services
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"monoex_backend/internal/config"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrWeakPassword = errors.New("password does not meet the policy")
+
+const (
+	algorithmArgon2id = "argon2id"
+	algorithmBcrypt   = "bcrypt"
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// Встроенный список распространённых паролей; дополняется файлом из weak_list_path
+//
+//go:embed weak_passwords.txt
+var builtinWeakPasswords string
+
+// PasswordHasher хэширует пароли выбранным в конфиге алгоритмом и проверяет
+// политику. Хэш хранится в самоописывающем формате:
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>  (PHC, base64 без паддинга)
+//	$2a$12$...                                   (bcrypt)
+//
+// поэтому старые хэши продолжают проверяться после смены алгоритма.
+type PasswordHasher struct {
+	cfg  config.PasswordConfig
+	weak map[string]struct{}
+}
+
+func NewPasswordHasher(cfg config.PasswordConfig) *PasswordHasher {
+	if cfg.Algorithm != algorithmArgon2id && cfg.Algorithm != algorithmBcrypt {
+		log.Printf("⚠️ Unknown password algorithm %q, falling back to %s", cfg.Algorithm, algorithmArgon2id)
+		cfg.Algorithm = algorithmArgon2id
+	}
+
+	h := &PasswordHasher{cfg: cfg, weak: map[string]struct{}{}}
+	h.addWeak(builtinWeakPasswords)
+	if cfg.WeakListPath != "" {
+		data, err := os.ReadFile(cfg.WeakListPath)
+		if err != nil {
+			log.Printf("⚠️ Could not read weak password list %s: %v", cfg.WeakListPath, err)
+		} else {
+			h.addWeak(string(data))
+		}
+	}
+	return h
+}
+
+// Validate проверяет пароль на соответствие политике
+func (h *PasswordHasher) Validate(password string) error {
+	if utf8.RuneCountInString(password) < h.cfg.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters long", ErrWeakPassword, h.cfg.MinLength)
+	}
+	if _, ok := h.weak[strings.ToLower(password)]; ok {
+		return fmt.Errorf("%w: password is too common", ErrWeakPassword)
+	}
+	return nil
+}
+
+// Hash возвращает закодированный хэш пароля по текущим параметрам
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	if h.cfg.Algorithm == algorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cfg.BcryptCost)
+		return string(hash), err
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	params := h.argon2Params()
+	key := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, argon2KeyLength)
+	return encodeArgon2(params, salt, key), nil
+}
+
+// Verify сверяет пароль с хэшем. needsRehash = true, если хэш сделан другим
+// алгоритмом или с другими параметрами и его стоит пересчитать.
+func (h *PasswordHasher) Verify(password, encoded string) (ok, needsRehash bool) {
+	if strings.HasPrefix(encoded, "$"+algorithmArgon2id+"$") {
+		params, salt, key, err := decodeArgon2(encoded)
+		if err != nil {
+			return false, false
+		}
+		actual := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(actual, key) != 1 {
+			return false, false
+		}
+		return true, h.cfg.Algorithm != algorithmArgon2id || params != h.argon2Params() ||
+			len(salt) != argon2SaltLength || len(key) != argon2KeyLength
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)) != nil {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return true, h.cfg.Algorithm != algorithmBcrypt || err != nil || cost != h.cfg.BcryptCost
+}
+
+func (h *PasswordHasher) addWeak(list string) {
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			h.weak[strings.ToLower(line)] = struct{}{}
+		}
+	}
+}
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+func (h *PasswordHasher) argon2Params() argon2Params {
+	return argon2Params{
+		memory:      uint32(h.cfg.Argon2Memory),
+		iterations:  uint32(h.cfg.Argon2Iterations),
+		parallelism: uint8(h.cfg.Argon2Parallelism),
+	}
+}
+
+var argon2Encoding = base64.RawStdEncoding
+
+func encodeArgon2(p argon2Params, salt, key []byte) string {
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", algorithmArgon2id, argon2.Version,
+		p.memory, p.iterations, p.parallelism,
+		argon2Encoding.EncodeToString(salt), argon2Encoding.EncodeToString(key))
+}
+
+func decodeArgon2(encoded string) (p argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return p, nil, nil, errors.New("malformed argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return p, nil, nil, err
+	}
+	if version != argon2.Version {
+		return p, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return p, nil, nil, err
+	}
+
+	if salt, err = argon2Encoding.DecodeString(parts[4]); err != nil {
+		return p, nil, nil, err
+	}
+	if key, err = argon2Encoding.DecodeString(parts[5]); err != nil {
+		return p, nil, nil, err
+	}
+	if len(key) == 0 {
+		return p, nil, nil, errors.New("malformed argon2id hash")
+	}
+	return p, salt, key, nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"monoex_backend/internal/config"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+	"time"
+)
+
+var ErrPreviewNotFound = errors.New("preview link not found")
+
+// Самая долгая ссылка предпросмотра, которую можно выдать
+const maxPreviewTTL = 30 * 24 * time.Hour
+
+// PreviewService выдаёт подписанные ссылки на предпросмотр новости в любом статусе.
+// Токен — JWT типа preview; его jti хранится в БД, чтобы ссылку можно было отозвать.
+type PreviewService struct {
+	db     *sql.DB
+	repo   *repositories.PreviewRepository
+	news   *NewsService
+	tokens *TokenService
+	audit  *AuditService
+	ttl    time.Duration
+}
+
+func NewPreviewService(db *sql.DB, cfg config.AuthConfig, news *NewsService, audit *AuditService) *PreviewService {
+	return &PreviewService{
+		db:     db,
+		repo:   repositories.NewPreviewRepository(db),
+		news:   news,
+		tokens: NewTokenService(cfg.TokenSecret),
+		audit:  audit,
+		ttl:    time.Duration(cfg.PreviewTokenTTL) * time.Hour,
+	}
+}
+
+// Create выдаёт ссылку на новость newsID; ttl 0 — срок из конфига
+func (s *PreviewService) Create(ctx context.Context, newsID int, ttl time.Duration) (*models.PreviewTokenCreated, error) {
+	if ttl == 0 {
+		ttl = s.ttl
+	}
+	if ttl < time.Hour || ttl > maxPreviewTTL {
+		return nil, errors.New("preview ttl must be between 1 and 720 hours")
+	}
+	if _, err := s.news.repo.GetByID(ctx, newsID); err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	jti, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	token, expiresAt, err := s.tokens.Sign(TokenClaims{Subject: newsID, Type: TokenTypePreview, ID: jti}, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &models.PreviewToken{ID: jti, NewsID: newsID, ExpiresAt: expiresAt.UTC()}
+	if admin := AdminFromContext(ctx); admin != nil {
+		createdBy := admin.ID
+		preview.CreatedBy = &createdBy
+	}
+
+	err = repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, preview, time.Now().UTC()); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditShare, models.EntityNews, newsID, nil, preview)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.PreviewTokenCreated{PreviewToken: preview, Token: token, URL: "/news/preview/" + token}, nil
+}
+
+// List возвращает все выданные ссылки новости, включая истёкшие и отозванные
+func (s *PreviewService) List(ctx context.Context, newsID int) ([]*models.PreviewToken, error) {
+	if _, err := s.news.repo.GetByID(ctx, newsID); err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	previews, err := s.repo.ListByNews(ctx, newsID)
+	if err != nil {
+		return nil, err
+	}
+	if previews == nil {
+		previews = []*models.PreviewToken{}
+	}
+	return previews, nil
+}
+
+// Revoke отзывает ссылку; повторный отзыв ничего не меняет
+func (s *PreviewService) Revoke(ctx context.Context, newsID int, id string) error {
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.Get(ctx, id)
+		if err == sql.ErrNoRows || (err == nil && before.NewsID != newsID) {
+			return ErrPreviewNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if before.RevokedAt != nil {
+			return nil
+		}
+		if err := s.repo.Revoke(ctx, id, time.Now().UTC()); err != nil {
+			return err
+		}
+		after, err := s.repo.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditRevoke, models.EntityNews, newsID, before, after)
+	})
+}
+
+// Open проверяет токен и возвращает новость для предпросмотра на языке language.
+// Неверный, истёкший и отозванный токен — ErrInvalidToken.
+func (s *PreviewService) Open(ctx context.Context, token, language string) (*models.NewsPreview, error) {
+	claims, err := s.tokens.Parse(token, TokenTypePreview)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	preview, err := s.repo.Get(ctx, claims.ID)
+	if err == sql.ErrNoRows {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if preview.RevokedAt != nil || preview.NewsID != claims.Subject || !preview.ExpiresAt.After(time.Now().UTC()) {
+		return nil, ErrInvalidToken
+	}
+
+	news, err := s.news.Preview(ctx, preview.NewsID, language)
+	if err == ErrNotFound {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.NewsPreview{News: news, Preview: true, PreviewExpiresAt: preview.ExpiresAt}, nil
+}
@@ -6,14 +6,17 @@ import (
 	"errors"
 	"monoex_backend/internal/models"
 	"monoex_backend/internal/repositories"
+	"time"
 )
 
 type ReviewService struct {
-	repo *repositories.ReviewRepository
+	db    *sql.DB
+	repo  *repositories.ReviewRepository
+	audit *AuditService
 }
 
-func NewReviewService(repo *repositories.ReviewRepository) *ReviewService {
-	return &ReviewService{repo: repo}
+func NewReviewService(db *sql.DB, repo *repositories.ReviewRepository, audit *AuditService) *ReviewService {
+	return &ReviewService{db: db, repo: repo, audit: audit}
 }
 
 // Create new review
@@ -21,7 +24,12 @@ func (s *ReviewService) Create(ctx context.Context, r *models.Review) error {
 	if r.CompanyName == "" || r.ServiceType == "" {
 		return errors.New("company name and service type are required")
 	}
-	return s.repo.Create(ctx, r)
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, r); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditCreate, models.EntityReview, r.ID, nil, r)
+	})
 }
 
 // Get by ID
@@ -57,15 +65,53 @@ func (s *ReviewService) Update(ctx context.Context, r *models.Review) error {
 	if r.ID == 0 {
 		return errors.New("id is required for update")
 	}
-	return s.repo.Update(ctx, r)
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, r.ID)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Update(ctx, r); err != nil {
+			return err
+		}
+
+		after, err := s.repo.GetByID(ctx, r.ID)
+		if err != nil {
+			return err
+		}
+		*r = *after
+		return s.audit.Record(ctx, models.AuditUpdate, models.EntityReview, r.ID, before, after)
+	})
 }
 
-// Delete by ID
+// Delete переносит запись в корзину (восстановить или удалить окончательно — TrashService)
 func (s *ReviewService) Delete(ctx context.Context, id int) error {
 	if id == 0 {
 		return errors.New("id is required")
 	}
-	return s.repo.Delete(ctx, id)
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		before, err := s.repo.GetByID(ctx, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SoftDelete(ctx, id, time.Now().UTC()); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditDelete, models.EntityReview, id, before, nil)
+	})
+}
+
+// RecordUpload фиксирует в журнале загрузку PDF отзыва
+func (s *ReviewService) RecordUpload(ctx context.Context, url string) error {
+	return s.audit.Record(ctx, models.AuditUpload, models.EntityFile, 0, nil, map[string]string{
+		"entity_type": models.EntityReview,
+		"url":         url,
+	})
 }
 
 // Get total count of reviews
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+	"sort"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Поля, которые меняются при каждом сохранении и в diff только мешают
+var revisionDiffIgnored = map[string]bool{"updated_at": true}
+
+type RevisionService struct {
+	repo *repositories.RevisionRepository
+}
+
+func NewRevisionService(db *sql.DB) *RevisionService {
+	return &RevisionService{repo: repositories.NewRevisionRepository(db)}
+}
+
+// Record сохраняет снимок записи от имени админа из контекста.
+// Вызывать внутри той же транзакции, что и сохранение.
+func (s *RevisionService) Record(ctx context.Context, entityType string, entityID int, content any) error {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	rev := &models.Revision{EntityType: entityType, EntityID: entityID, Content: data}
+	if admin := AdminFromContext(ctx); admin != nil {
+		authorID := admin.ID
+		rev.AuthorID = &authorID
+		rev.AuthorName = admin.Username
+	}
+	return s.repo.Create(ctx, rev)
+}
+
+func (s *RevisionService) List(ctx context.Context, entityType string, entityID, limit, offset int) ([]*models.Revision, int, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	revisions, err := s.repo.List(ctx, entityType, entityID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.repo.Count(ctx, entityType, entityID)
+	return revisions, total, err
+}
+
+func (s *RevisionService) Get(ctx context.Context, entityType string, entityID, revision int) (*models.Revision, error) {
+	rev, err := s.repo.Get(ctx, entityType, entityID, revision)
+	if err == sql.ErrNoRows {
+		return nil, ErrRevisionNotFound
+	}
+	return rev, err
+}
+
+// Diff сравнивает две ревизии по полям. to = 0 — последняя ревизия,
+// from = 0 — предыдущая перед to.
+func (s *RevisionService) Diff(ctx context.Context, entityType string, entityID, from, to int) (*models.RevisionDiff, error) {
+	if to == 0 {
+		latest, err := s.repo.Latest(ctx, entityType, entityID)
+		if err != nil {
+			return nil, err
+		}
+		to = latest
+	}
+	if from == 0 {
+		from = to - 1
+	}
+
+	before, err := s.Get(ctx, entityType, entityID, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.Get(ctx, entityType, entityID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var beforeFields, afterFields map[string]json.RawMessage
+	if err := json.Unmarshal(before.Content, &beforeFields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after.Content, &afterFields); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]bool{}
+	for field := range beforeFields {
+		fields[field] = true
+	}
+	for field := range afterFields {
+		fields[field] = true
+	}
+
+	diff := &models.RevisionDiff{From: from, To: to, Changes: []models.FieldChange{}}
+	for field := range fields {
+		if revisionDiffIgnored[field] {
+			continue
+		}
+		if !jsonEqual(beforeFields[field], afterFields[field]) {
+			diff.Changes = append(diff.Changes, models.FieldChange{
+				Field:  field,
+				Before: beforeFields[field],
+				After:  afterFields[field],
+			})
+		}
+	}
+	sort.Slice(diff.Changes, func(i, j int) bool { return diff.Changes[i].Field < diff.Changes[j].Field })
+	return diff, nil
+}
+
+// jsonEqual сравнивает значения без учёта форматирования
+// (снимки из миграции пишет Postgres, остальные — encoding/json)
+func jsonEqual(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	ca, _ := json.Marshal(va)
+	cb, _ := json.Marshal(vb)
+	return bytes.Equal(ca, cb)
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"errors"
+	"monoex_backend/internal/models"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrInvalidCanonicalURL = errors.New("canonical_url must be an absolute http(s) URL")
+	ErrInvalidOGImage      = errors.New("og_image must be an absolute http(s) URL or an /uploads/ path")
+)
+
+// normalizeSEO обрезает пробелы в SEO-полях и проверяет адреса; пустые поля допустимы
+func normalizeSEO(f *models.SEOFields) error {
+	f.MetaTitle = strings.TrimSpace(f.MetaTitle)
+	f.MetaDescription = strings.TrimSpace(f.MetaDescription)
+	f.CanonicalURL = strings.TrimSpace(f.CanonicalURL)
+	f.OGImage = strings.TrimSpace(f.OGImage)
+
+	if f.CanonicalURL != "" && !isAbsoluteHTTPURL(f.CanonicalURL) {
+		return ErrInvalidCanonicalURL
+	}
+	if f.OGImage != "" && !isAbsoluteHTTPURL(f.OGImage) &&
+		(!strings.HasPrefix(f.OGImage, "/uploads/") || strings.Contains(f.OGImage, "..")) {
+		return ErrInvalidOGImage
+	}
+	return nil
+}
+
+func isAbsoluteHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
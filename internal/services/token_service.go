@@ -0,0 +1,102 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+	TokenTypePreview = "preview" // ссылка предпросмотра новости
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Полезная нагрузка подписанного токена (формат JWT, алгоритм HS256)
+type TokenClaims struct {
+	Subject   int    `json:"sub"`
+	Username  string `json:"usr,omitempty"`
+	Role      string `json:"role,omitempty"`
+	Type      string `json:"typ"`
+	ID        string `json:"jti,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// TokenService подписывает и проверяет токены общим секретом
+type TokenService struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewTokenService(secret string) *TokenService {
+	return &TokenService{secret: []byte(secret), now: time.Now}
+}
+
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Sign выставляет iat/exp и возвращает подписанный токен
+func (s *TokenService) Sign(claims TokenClaims, ttl time.Duration) (string, time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(ttl)
+	claims.IssuedAt = now.Unix()
+	claims.ExpiresAt = expiresAt.Unix()
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + s.signature(unsigned), expiresAt, nil
+}
+
+// Parse проверяет подпись, срок действия и тип токена
+func (s *TokenService) Parse(token, expectedType string) (*TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return nil, ErrInvalidToken
+	}
+
+	expected := s.signature(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims TokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.Type != expectedType || s.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+func (s *TokenService) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Случайный идентификатор для jti и подобных целей
+func randomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+)
+
+// Секрет из RFC 6238, приложение B: ASCII "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// Векторы RFC 6238 (SHA1), у нас 6 цифр — младшие цифры 8-значных кодов
+func TestTOTPCodeRFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		if got := totpCode([]byte("12345678901234567890"), tt.unix/totpPeriod); got != tt.code {
+			t.Errorf("T=%d: code = %s, want %s", tt.unix, got, tt.code)
+		}
+	}
+}
+
+func TestMatchTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := now.Unix() / totpPeriod
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		step   int64
+		ok     bool
+	}{
+		{"current step", rfcSecret, "050471", step, true},
+		{"lowercase secret and spaces", strings.ToLower(rfcSecret), " 050471 ", step, true},
+		{"previous step", rfcSecret, totpCode([]byte("12345678901234567890"), step-1), step - 1, true},
+		{"next step", rfcSecret, totpCode([]byte("12345678901234567890"), step+1), step + 1, true},
+		{"outside skew", rfcSecret, totpCode([]byte("12345678901234567890"), step-2), 0, false},
+		{"wrong code", rfcSecret, "000000", 0, false},
+		{"invalid secret", "not base32!", "050471", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := matchTOTP(tt.secret, tt.code, now)
+			if ok != tt.ok || got != tt.step {
+				t.Errorf("matchTOTP = (%d, %v), want (%d, %v)", got, ok, tt.step, tt.ok)
+			}
+		})
+	}
+}
+
+// Повторный ввод кода того же шага и код более раннего шага после позднего отклоняются
+func TestCheckSecondFactorRejectsReplay(t *testing.T) {
+	db := sql.OpenDB(&stepConnector{last: map[int64]int64{}})
+	defer db.Close()
+	s := &AdminService{repo: repositories.NewAdminRepository(db)}
+	admin := &models.Admin{ID: 1, TOTPSecret: rfcSecret, TOTPEnabled: true}
+
+	key := []byte("12345678901234567890")
+	step := time.Now().Unix() / totpPeriod
+	current, previous := totpCode(key, step), totpCode(key, step-1)
+	ctx := context.Background()
+
+	if err := s.checkSecondFactor(ctx, admin, current); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := s.checkSecondFactor(ctx, admin, current); !errors.Is(err, ErrInvalidOTP) {
+		t.Errorf("replay of the same step: err = %v, want ErrInvalidOTP", err)
+	}
+	if err := s.checkSecondFactor(ctx, admin, previous); !errors.Is(err, ErrInvalidOTP) {
+		t.Errorf("earlier step after a later one: err = %v, want ErrInvalidOTP", err)
+	}
+	if err := s.checkSecondFactor(ctx, admin, ""); !errors.Is(err, ErrOTPRequired) {
+		t.Errorf("empty code: err = %v, want ErrOTPRequired", err)
+	}
+}
+
+// stepConnector — драйвер БД, который понимает только UPDATE из AdminRepository.UseTOTPStep
+// и хранит totp_last_step в памяти с тем же условием, что и запрос
+type stepConnector struct {
+	mu   sync.Mutex
+	last map[int64]int64 // id админа → последний принятый шаг
+}
+
+func (c *stepConnector) Connect(context.Context) (driver.Conn, error) { return stepConn{c}, nil }
+func (c *stepConnector) Driver() driver.Driver                        { return nil }
+
+type stepConn struct{ c *stepConnector }
+
+func (stepConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (stepConn) Close() error                        { return nil }
+func (stepConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (conn stepConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if !strings.Contains(query, "totp_last_step = $1") || len(args) != 2 {
+		return nil, errors.New("unexpected query: " + query)
+	}
+	step, id := args[0].Value.(int64), args[1].Value.(int64)
+
+	conn.c.mu.Lock()
+	defer conn.c.mu.Unlock()
+	if last, ok := conn.c.last[id]; ok && last >= step {
+		return driver.RowsAffected(0), nil
+	}
+	conn.c.last[id] = step
+	return driver.RowsAffected(1), nil
+}
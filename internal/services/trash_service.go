@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"monoex_backend/internal/config"
+	"monoex_backend/internal/models"
+	"monoex_backend/internal/repositories"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrUnknownContentType = errors.New("unknown content type, expected news, legislation or review")
+
+// Сколько записей корзины удаляется окончательно в одной транзакции
+const purgeBatchSize = 100
+
+// Права на просмотр и правку каждого типа контента; порядок — порядок типов в корзине
+var trashTypes = []struct {
+	entityType  string
+	read, write models.Permission
+}{
+	{models.EntityNews, models.PermNewsRead, models.PermNewsWrite},
+	{models.EntityLegislation, models.PermLegislationRead, models.PermLegislationWrite},
+	{models.EntityReview, models.PermReviewsRead, models.PermReviewsWrite},
+}
+
+// TrashService — корзина удалённых новостей, законов и отзывов: просмотр, восстановление
+// и окончательное удаление вместе с загруженными файлами (вручную или по сроку хранения)
+type TrashService struct {
+	db        *sql.DB
+	repo      *repositories.TrashRepository
+	audit     *AuditService
+	retention time.Duration
+}
+
+func NewTrashService(db *sql.DB, cfg config.TrashConfig, audit *AuditService) *TrashService {
+	return &TrashService{
+		db:        db,
+		repo:      repositories.NewTrashRepository(db),
+		audit:     audit,
+		retention: time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+	}
+}
+
+// List возвращает корзину типа entityType (пустой — все типы, которые админ может читать)
+// и общее число записей
+func (s *TrashService) List(ctx context.Context, entityType string, limit, offset int) ([]*models.TrashItem, int, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	var types []string
+	for _, t := range trashTypes {
+		if entityType != "" && t.entityType != entityType {
+			continue
+		}
+		if HasPermission(ctx, t.read) {
+			types = append(types, t.entityType)
+		} else if entityType != "" {
+			return nil, 0, ErrForbidden
+		}
+	}
+	if entityType != "" && len(types) == 0 {
+		return nil, 0, ErrUnknownContentType
+	}
+	if len(types) == 0 {
+		return []*models.TrashItem{}, 0, nil
+	}
+
+	items, err := s.repo.List(ctx, types, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.repo.Count(ctx, types)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, item := range items {
+		item.PurgeAt = item.DeletedAt.Add(s.retention)
+	}
+	return items, total, nil
+}
+
+// Restore возвращает запись из корзины
+func (s *TrashService) Restore(ctx context.Context, entityType string, id int) error {
+	if err := s.checkWrite(ctx, entityType); err != nil {
+		return err
+	}
+	return repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		item, err := s.repo.Get(ctx, entityType, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Restore(ctx, entityType, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditRestore, entityType, id, nil, item)
+	})
+}
+
+// Purge окончательно удаляет запись из корзины и её файл, если на него больше никто не ссылается
+func (s *TrashService) Purge(ctx context.Context, entityType string, id int) error {
+	if err := s.checkWrite(ctx, entityType); err != nil {
+		return err
+	}
+
+	var item *models.TrashItem
+	err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+		var err error
+		item, err = s.repo.Get(ctx, entityType, id)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return s.purge(ctx, item)
+	})
+	if err != nil {
+		return err
+	}
+	s.removeFile(ctx, item.FilePath)
+	return nil
+}
+
+// PurgeExpired окончательно удаляет записи, пролежавшие в корзине дольше срока хранения.
+// Безопасно вызывать с нескольких экземпляров: строки берутся FOR UPDATE SKIP LOCKED.
+// Возвращает число удалённых записей.
+func (s *TrashService) PurgeExpired(ctx context.Context) (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+
+	total := 0
+	for _, t := range trashTypes {
+		for {
+			var files []string
+			err := repositories.WithinTx(ctx, s.db, func(ctx context.Context) error {
+				ids, err := s.repo.Expired(ctx, t.entityType, time.Now().UTC().Add(-s.retention), purgeBatchSize)
+				if err != nil {
+					return err
+				}
+				for _, id := range ids {
+					item, err := s.repo.Get(ctx, t.entityType, id)
+					if err != nil {
+						return err
+					}
+					// Актёра в контексте нет — в журнале это системное действие
+					if err := s.purge(ctx, item); err != nil {
+						return err
+					}
+					files = append(files, item.FilePath)
+				}
+				return nil
+			})
+			if err != nil {
+				return total, err
+			}
+			for _, file := range files {
+				s.removeFile(ctx, file)
+			}
+			total += len(files)
+			if len(files) < purgeBatchSize {
+				break
+			}
+		}
+	}
+	return total, nil
+}
+
+func (s *TrashService) purge(ctx context.Context, item *models.TrashItem) error {
+	if err := s.repo.Purge(ctx, item.EntityType, item.ID); err != nil {
+		return err
+	}
+	return s.audit.Record(ctx, models.AuditPurge, item.EntityType, item.ID, item, nil)
+}
+
+func (s *TrashService) checkWrite(ctx context.Context, entityType string) error {
+	for _, t := range trashTypes {
+		if t.entityType != entityType {
+			continue
+		}
+		if !HasPermission(ctx, t.write) {
+			return ErrForbidden
+		}
+		return nil
+	}
+	return ErrUnknownContentType
+}
+
+// removeFile удаляет загруженный файл записи (/uploads/...), если на него не ссылается
+// ни одна другая запись. Ошибки только логируем: запись уже удалена.
+func (s *TrashService) removeFile(ctx context.Context, path string) {
+	if !strings.HasPrefix(path, "/uploads/") || strings.Contains(path, "..") {
+		return
+	}
+	inUse, err := s.repo.FileInUse(ctx, path)
+	if err != nil {
+		log.Printf("⚠️ Failed to check usage of %s: %v", path, err)
+		return
+	}
+	if inUse {
+		return
+	}
+	if err := os.Remove("." + path); err != nil && !os.IsNotExist(err) {
+		log.Printf("⚠️ Failed to remove %s: %v", path, err)
+	}
+}
@@ -0,0 +1,67 @@
+// Package sitemap сериализует sitemap и sitemap index (sitemaps.org 0.9).
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// MaxURLs — предел протокола на один файл (и 50 МБ без сжатия,
+// до которых при наших длинах ссылок не доходит)
+const MaxURLs = 50000
+
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []entry  `xml:"url"`
+}
+
+type index struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Sitemaps []entry  `xml:"sitemap"`
+}
+
+type entry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// URLSet строит файл sitemap со ссылками на страницы
+func URLSet(urls []URL) ([]byte, error) {
+	doc := urlSet{Xmlns: namespace, URLs: entries(urls)}
+	return marshal(doc)
+}
+
+// Index строит sitemap index; URL — адреса файлов sitemap
+func Index(sitemaps []URL) ([]byte, error) {
+	doc := index{Xmlns: namespace, Sitemaps: entries(sitemaps)}
+	return marshal(doc)
+}
+
+func entries(urls []URL) []entry {
+	out := make([]entry, 0, len(urls))
+	for _, u := range urls {
+		e := entry{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			e.LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func marshal(doc any) ([]byte, error) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
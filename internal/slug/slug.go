@@ -0,0 +1,86 @@
+// Package slug строит ASCII-ссылки из заголовков на русском и казахском.
+package slug
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxLength — предел длины ссылки; при обрезке режем по дефису
+const MaxLength = 80
+
+var validSlug = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Русский алфавит — по упрощённой схеме (как в загранпаспортах),
+// казахские буквы — без диакритики, по латинскому алфавиту 2021 года
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+
+	'ә': "a", 'ғ': "g", 'қ': "q", 'ң': "n", 'ө': "o", 'ұ': "u", 'ү': "u",
+	'һ': "h", 'і': "i",
+}
+
+// Make транслитерирует текст и оставляет только [a-z0-9-].
+// Пустой результат возможен, если в тексте нет ни букв, ни цифр.
+func Make(text string) string {
+	var b strings.Builder
+	pendingDash := false
+
+	for _, r := range strings.ToLower(text) {
+		var part string
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			part = string(r)
+		case translit[r] != "":
+			part = translit[r]
+		case r == 'ъ' || r == 'ь', r == '\'' || r == '’':
+			// Знаки и апострофы не разрывают слово
+			continue
+		case unicode.IsMark(r):
+			continue
+		default:
+			// Пробелы, пунктуация и прочие символы — разделитель слов
+			pendingDash = b.Len() > 0
+			continue
+		}
+
+		if pendingDash {
+			b.WriteByte('-')
+			pendingDash = false
+		}
+		b.WriteString(part)
+	}
+
+	return truncate(b.String())
+}
+
+// Valid сообщает, что ссылка уже в каноническом виде
+func Valid(s string) bool {
+	return len(s) <= MaxLength && validSlug.MatchString(s)
+}
+
+// WithSuffix добавляет номер для разрешения коллизий: "title-2"
+func WithSuffix(base string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	if len(base)+len(suffix) > MaxLength {
+		base = strings.TrimRight(base[:MaxLength-len(suffix)], "-")
+	}
+	return base + suffix
+}
+
+func truncate(s string) string {
+	if len(s) <= MaxLength {
+		return s
+	}
+	s = s[:MaxLength]
+	if i := strings.LastIndexByte(s, '-'); i > MaxLength/2 {
+		s = s[:i]
+	}
+	return strings.TrimRight(s, "-")
+}
@@ -0,0 +1,130 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"russian", "Новости Казахстана", "novosti-kazakhstana"},
+		{"hard sign joins the word", "Съезд партии", "sezd-partii"},
+		{"multi-letter transliteration", "Ёлка, щука и чай", "yolka-shchuka-i-chay"},
+		{"kazakh", "Әділет және құқық", "adilet-zhane-quqyq"},
+		{"kazakh letters", "ғылым өнер үміт һ ң", "gylym-oner-umit-h-n"},
+		{"kazakh uppercase", "ҚАЗАҚСТАН РЕСПУБЛИКАСЫНЫҢ ЗАҢЫ", "qazaqstan-respublikasynyn-zany"},
+		{"latin and digits", "COVID-19: Update 2024", "covid-19-update-2024"},
+		{"punctuation collapses", "  Закон №123 — о налогах!!!  ", "zakon-123-o-nalogakh"},
+		{"apostrophes join the word", "Don't stop, Қазақстан’ға", "dont-stop-qazaqstanga"},
+		{"combining marks dropped", "Cafe\u0301 de\u0301ja\u0300", "cafe-deja"},
+		{"punctuation only", "!!! — ???", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Make(tt.text)
+			if got != tt.want {
+				t.Errorf("Make(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			if got != "" && !Valid(got) {
+				t.Errorf("Make(%q) = %q is not a valid slug", tt.text, got)
+			}
+		})
+	}
+}
+
+func TestMakeTruncates(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{
+			// 13 слов по 5 букв с дефисами — 77 символов, четырнадцатое не влезает
+			name: "cut at the last hyphen",
+			text: strings.Repeat("слово ", 30),
+			want: strings.TrimSuffix(strings.Repeat("slovo-", 13), "-"),
+		},
+		{
+			name: "single long word",
+			text: strings.Repeat("қ", 100),
+			want: strings.Repeat("q", MaxLength),
+		},
+		{
+			// Дефис в первой половине — режем по длине, а не по нему
+			name: "hyphen too early",
+			text: "ab " + strings.Repeat("a", 100),
+			want: "ab-" + strings.Repeat("a", MaxLength-3),
+		},
+		{
+			name: "no trailing hyphen",
+			text: strings.Repeat("a", MaxLength-1) + " " + strings.Repeat("b", 10),
+			want: strings.Repeat("a", MaxLength-1),
+		},
+		{
+			name: "exactly max length",
+			text: strings.Repeat("a", MaxLength),
+			want: strings.Repeat("a", MaxLength),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Make(tt.text)
+			if got != tt.want {
+				t.Errorf("Make() = %q (%d), want %q (%d)", got, len(got), tt.want, len(tt.want))
+			}
+			if !Valid(got) {
+				t.Errorf("Make() = %q is not a valid slug", got)
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		slug string
+		want bool
+	}{
+		{"novosti-2024", true},
+		{"a", true},
+		{strings.Repeat("a", MaxLength), true},
+		{strings.Repeat("a", MaxLength+1), false},
+		{"", false},
+		{"Novosti", false},
+		{"novosti--2024", false},
+		{"-novosti", false},
+		{"novosti-", false},
+		{"новости", false},
+		{"novosti_2024", false},
+	}
+	for _, tt := range tests {
+		if got := Valid(tt.slug); got != tt.want {
+			t.Errorf("Valid(%q) = %v, want %v", tt.slug, got, tt.want)
+		}
+	}
+}
+
+func TestWithSuffix(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		n    int
+		want string
+	}{
+		{"short", "novosti", 2, "novosti-2"},
+		{"fills max length", strings.Repeat("a", MaxLength-2), 2, strings.Repeat("a", MaxLength-2) + "-2"},
+		{"long base is cut", strings.Repeat("a", MaxLength), 2, strings.Repeat("a", MaxLength-2) + "-2"},
+		{"no double hyphen after cut", strings.Repeat("a", MaxLength-4) + "-bbb", 10, strings.Repeat("a", MaxLength-4) + "-10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WithSuffix(tt.base, tt.n)
+			if got != tt.want {
+				t.Errorf("WithSuffix() = %q, want %q", got, tt.want)
+			}
+			if !Valid(got) {
+				t.Errorf("WithSuffix() = %q is not a valid slug", got)
+			}
+		})
+	}
+}